@@ -0,0 +1,622 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Option describes a command line option. Options with an Arg take a
+// value, given as --long=VALUE, --long VALUE, -sVALUE, or -s VALUE.
+// Options without one are boolean flags, and their short forms may be
+// combined, as in -abc.
+type Option struct {
+	Long    string   // Long name, without the leading "--"
+	Short   rune     // Short name, or zero if there is none
+	Arg     string   // Name of the value in help text, or "" for a flag
+	Default string   // Value if the option is not given
+	Help    string   // Description for the help text
+	Values  []string // Possible values, for shell completion and defaults
+	Dynamic string   // Kind of value completed by the program, e.g., "games"
+}
+
+// Command is one subcommand of an App
+type Command struct {
+	Name    string               // Name typed by the user
+	Args    string               // Synopsis of the arguments, e.g., "[GAME]"
+	Summary string               // One line description
+	Help    string               // Longer description for the help text
+	Options []*Option            // Options specific to this command
+	Run     func(*Context) error // Function that carries out the command
+	Dynamic string               // Kind of argument completed by the program
+	Hidden  bool                 // True to leave out of help and completion
+}
+
+// App is a program made up of subcommands
+type App struct {
+	Name     string     // Program name
+	Summary  string     // One line description
+	Help     string     // Text shown after the generated help
+	Options  []*Option  // Options accepted by every command
+	Commands []*Command // The subcommands
+	Default  string     // Command run when none is named
+	Version  string     // Shown by --version, which is omitted if empty
+	Build    string     // Build details shown by --version after Version
+	Stdout   io.Writer  // Where help is written, os.Stdout if nil
+
+	// CompleteCommand is the hidden command that shell completion
+	// scripts run as "CompleteCommand KIND" to list the possible values
+	// of options and arguments with a Dynamic kind, one per line.
+	CompleteCommand string
+
+	defaults        map[string]string            // Long option name to value set by SetDefault
+	commandDefaults map[string]map[string]string // Command name to long option name to value
+}
+
+// Context holds the parsed command line for a command's Run function
+type Context struct {
+	App     *App
+	Command *Command
+	Args    []string          // Positional arguments
+	values  map[string]string // Option values by long name
+	set     map[string]bool   // True for options given on the command line
+}
+
+// UsageError is returned for invalid command lines
+type UsageError struct {
+	Command *Command
+	Message string
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// ErrHelp is returned by Parse and Run when help was requested and
+// shown.
+var ErrHelp = errors.New("help requested")
+
+// ErrVersion is returned by Parse and Run when the version was
+// requested and shown.
+var ErrVersion = errors.New("version requested")
+
+// helpOption is added to every command
+var helpOption = &Option{Long: "help", Short: 'h', Help: "Show this help"}
+
+// versionOption is added to every command if the App has a Version
+var versionOption = &Option{Long: "version", Help: "Show the version and exit"}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Error returns the text of the usage error
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+// Lookup returns the command with the specified name, or nil
+func (a *App) Lookup(name string) *Command {
+	for _, cmd := range a.Commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Run parses the arguments (not including the program name) and runs
+// the selected command.
+func (a *App) Run(args []string) error {
+	ctx, err := a.Parse(args)
+	if err != nil {
+		return err
+	}
+	return ctx.Command.Run(ctx)
+}
+
+// Parse finds the command named in the arguments, or the default
+// command, and parses the options and positional arguments for it.
+// Options may come before or after the command name and may be mixed
+// with positional arguments. Everything after "--" is positional.
+func (a *App) Parse(args []string) (*Context, error) {
+
+	// Find the command: the first argument that is not an option or
+	// an option's value
+	cmd, cmdIndex := a.findCommand(args)
+	if cmd == nil {
+		return nil, &UsageError{nil, fmt.Sprintf("unknown command %q", a.Default)}
+	}
+	if cmdIndex >= 0 {
+		args = append(append([]string{}, args[:cmdIndex]...), args[cmdIndex+1:]...)
+	}
+
+	// Usage errors refer to the command only if it was named
+	usageCmd := cmd
+	if cmdIndex < 0 {
+		usageCmd = nil
+	}
+
+	ctx := &Context{
+		App:     a,
+		Command: cmd,
+		values:  make(map[string]string),
+		set:     make(map[string]bool),
+	}
+	options := a.optionsFor(cmd)
+	for _, opt := range options {
+		ctx.values[opt.Long] = a.defaultValue(cmd, opt)
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			ctx.Args = append(ctx.Args, args[i+1:]...)
+			i = len(args)
+
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			opt := findLong(options, name)
+			if opt == nil {
+				return nil, &UsageError{usageCmd, fmt.Sprintf("unknown option --%s", name)}
+			}
+			switch {
+			case opt.Arg == "" && hasValue:
+				return nil, &UsageError{usageCmd, fmt.Sprintf("option --%s does not take a value", name)}
+			case opt.Arg == "":
+				value = "true"
+			case !hasValue:
+				if i+1 >= len(args) {
+					return nil, &UsageError{usageCmd, fmt.Sprintf("option --%s requires a value", name)}
+				}
+				i++
+				value = args[i]
+			}
+			ctx.values[opt.Long] = value
+			ctx.set[opt.Long] = true
+
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			shorts := []rune(arg[1:])
+			for j := 0; j < len(shorts); j++ {
+				opt := findShort(options, shorts[j])
+				if opt == nil {
+					return nil, &UsageError{usageCmd, fmt.Sprintf("unknown option -%c", shorts[j])}
+				}
+				value := "true"
+				if opt.Arg != "" {
+					if j+1 < len(shorts) {
+						value = string(shorts[j+1:])
+					} else if i+1 < len(args) {
+						i++
+						value = args[i]
+					} else {
+						return nil, &UsageError{usageCmd, fmt.Sprintf("option -%c requires a value", shorts[j])}
+					}
+					j = len(shorts)
+				}
+				ctx.values[opt.Long] = value
+				ctx.set[opt.Long] = true
+			}
+
+		default:
+			ctx.Args = append(ctx.Args, arg)
+		}
+	}
+
+	// Show the help for the command, or for the program if no command
+	// was named
+	if ctx.Bool("help") {
+		if cmdIndex < 0 {
+			a.PrintHelp(a.stdout(), nil)
+		} else {
+			a.PrintHelp(a.stdout(), cmd)
+		}
+		return nil, ErrHelp
+	}
+	if ctx.Bool("version") {
+		fmt.Fprintln(a.stdout(), strings.TrimSpace(a.Name+" "+a.Version+" "+a.Build))
+		return nil, ErrVersion
+	}
+	return ctx, nil
+}
+
+// PrintHelp writes the help for a command, or for the whole program if
+// the command is nil.
+func (a *App) PrintHelp(w io.Writer, cmd *Command) {
+	if cmd == nil {
+		fmt.Fprintf(w, "Usage: %s [COMMAND] [OPTION]... [ARG]...\n\n", a.Name)
+		fmt.Fprintf(w, "%s\n\n", a.Summary)
+		fmt.Fprintf(w, "Commands:\n")
+		rows := [][2]string{}
+		for _, c := range a.visibleCommands() {
+			summary := c.Summary
+			if c.Name == a.Default {
+				summary = strings.TrimSpace(summary + " (default)")
+			}
+			rows = append(rows, [2]string{c.Name, summary})
+		}
+		writeRows(w, rows)
+		if def := a.Lookup(a.Default); def != nil && len(def.Options) > 0 {
+			fmt.Fprintf(w, "\nOptions for %s, the default command:\n", def.Name)
+			writeRows(w, a.optionRows(def, def.Options))
+		}
+		fmt.Fprintf(w, "\nOptions for all commands:\n")
+		writeRows(w, a.optionRows(nil, a.globalOptions()))
+		fmt.Fprintf(w, "\nRun '%s COMMAND --help' for the options of a command.\n", a.Name)
+		if a.Help != "" {
+			fmt.Fprintf(w, "\n%s\n", strings.TrimSpace(a.Help))
+		}
+		return
+	}
+
+	synopsis := fmt.Sprintf("%s %s [OPTION]...", a.Name, cmd.Name)
+	if cmd.Args != "" {
+		synopsis += " " + cmd.Args
+	}
+	fmt.Fprintf(w, "Usage: %s\n\n", synopsis)
+	fmt.Fprintf(w, "%s\n", cmd.Summary)
+	if cmd.Help != "" {
+		fmt.Fprintf(w, "\n%s\n", strings.TrimSpace(cmd.Help))
+	}
+	if len(cmd.Options) > 0 {
+		fmt.Fprintf(w, "\nOptions:\n")
+		writeRows(w, a.optionRows(cmd, cmd.Options))
+	}
+	fmt.Fprintf(w, "\nOptions for all commands:\n")
+	writeRows(w, a.optionRows(cmd, a.globalOptions()))
+}
+
+// CommandNames returns the names of all the commands that are not
+// hidden, sorted
+func (a *App) CommandNames() []string {
+	names := []string{}
+	for _, cmd := range a.visibleCommands() {
+		names = append(names, cmd.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AllOptions returns the options accepted by a command, including the
+// options accepted by every command.
+func (a *App) AllOptions(cmd *Command) []*Option {
+	return a.optionsFor(cmd)
+}
+
+// SetDefault changes the default value of the options with the long
+// name in every command, e.g., from a configuration file. The value
+// must be one of the Values of each of those options that has them.
+// The new default is used when parsing and shown in the help, but the
+// option definitions are not changed, so that the man page and
+// completions show the defaults of the program itself.
+func (a *App) SetDefault(name, value string) error {
+	found := false
+	if opt := findLong(a.Options, name); opt != nil {
+		if err := checkValue(nil, opt, value); err != nil {
+			return err
+		}
+		found = true
+	}
+	for _, cmd := range a.Commands {
+		if opt := findLong(cmd.Options, name); opt != nil {
+			if err := checkValue(cmd, opt, value); err != nil {
+				return err
+			}
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown option %q", name)
+	}
+	if a.defaults == nil {
+		a.defaults = map[string]string{}
+	}
+	a.defaults[name] = value
+	return nil
+}
+
+// SetCommandDefault is like SetDefault, but changes the default value
+// of the option only when running the command. It overrides a default
+// set by SetDefault.
+func (a *App) SetCommandDefault(command, name, value string) error {
+	cmd := a.Lookup(command)
+	if cmd == nil {
+		return fmt.Errorf("unknown command %q", command)
+	}
+	opt := findLong(a.optionsFor(cmd), name)
+	if opt == nil {
+		return fmt.Errorf("unknown option %q for the %s command", name, command)
+	}
+	if err := checkValue(cmd, opt, value); err != nil {
+		return err
+	}
+	if a.commandDefaults == nil {
+		a.commandDefaults = map[string]map[string]string{}
+	}
+	if a.commandDefaults[command] == nil {
+		a.commandDefaults[command] = map[string]string{}
+	}
+	a.commandDefaults[command][name] = value
+	return nil
+}
+
+// defaultValue returns the default value of an option of a command, as
+// changed by SetCommandDefault or SetDefault. The command may be nil
+// for the options of every command.
+func (a *App) defaultValue(cmd *Command, opt *Option) string {
+	if cmd != nil {
+		if value, ok := a.commandDefaults[cmd.Name][opt.Long]; ok {
+			return value
+		}
+	}
+	if value, ok := a.defaults[opt.Long]; ok {
+		return value
+	}
+	return opt.Default
+}
+
+// findCommand returns the command named in the arguments and its index,
+// or the default command and -1 if none is named.
+func (a *App) findCommand(args []string) (*Command, int) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return a.Lookup(a.Default), -1
+		case strings.HasPrefix(arg, "--"):
+			name, _, hasValue := strings.Cut(arg[2:], "=")
+			if opt := a.anyLong(name); opt != nil && opt.Arg != "" && !hasValue {
+				i++
+			}
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			shorts := []rune(arg[1:])
+			last := shorts[len(shorts)-1]
+			if opt := a.anyShort(last); opt != nil && opt.Arg != "" {
+				// The value is the next argument only if no earlier
+				// letter in the group takes a value
+				takesValue := true
+				for _, r := range shorts[:len(shorts)-1] {
+					if o := a.anyShort(r); o != nil && o.Arg != "" {
+						takesValue = false
+					}
+				}
+				if takesValue {
+					i++
+				}
+			}
+		default:
+			if cmd := a.Lookup(arg); cmd != nil {
+				return cmd, i
+			}
+			return a.Lookup(a.Default), -1
+		}
+	}
+	return a.Lookup(a.Default), -1
+}
+
+// visibleCommands returns the commands that are not hidden
+func (a *App) visibleCommands() []*Command {
+	commands := []*Command{}
+	for _, cmd := range a.Commands {
+		if !cmd.Hidden {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// optionsFor returns the global options followed by the command's
+func (a *App) optionsFor(cmd *Command) []*Option {
+	options := append([]*Option{}, a.Options...)
+	options = append(options, cmd.Options...)
+	return append(options, a.builtinOptions()...)
+}
+
+// globalOptions returns the options accepted by every command
+func (a *App) globalOptions() []*Option {
+	options := append([]*Option{}, a.Options...)
+	return append(options, a.builtinOptions()...)
+}
+
+// builtinOptions returns --help, and --version if there is a version
+func (a *App) builtinOptions() []*Option {
+	if a.Version == "" {
+		return []*Option{helpOption}
+	}
+	return []*Option{helpOption, versionOption}
+}
+
+// anyLong finds a long option in any command
+func (a *App) anyLong(name string) *Option {
+	if opt := findLong(a.Options, name); opt != nil {
+		return opt
+	}
+	for _, cmd := range a.Commands {
+		if opt := findLong(cmd.Options, name); opt != nil {
+			return opt
+		}
+	}
+	return nil
+}
+
+// anyShort finds a short option in any command
+func (a *App) anyShort(r rune) *Option {
+	if opt := findShort(a.Options, r); opt != nil {
+		return opt
+	}
+	for _, cmd := range a.Commands {
+		if opt := findShort(cmd.Options, r); opt != nil {
+			return opt
+		}
+	}
+	return nil
+}
+
+// stdout returns where help is written
+func (a *App) stdout() io.Writer {
+	if a.Stdout == nil {
+		return os.Stdout
+	}
+	return a.Stdout
+}
+
+// String returns the value of an option, or its default
+func (c *Context) String(name string) string {
+	return c.values[name]
+}
+
+// Bool returns true if a flag was given
+func (c *Context) Bool(name string) bool {
+	value, _ := strconv.ParseBool(c.values[name])
+	return value
+}
+
+// Int returns the value of an option as an integer
+func (c *Context) Int(name string) (int, error) {
+	value := c.values[name]
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &UsageError{c.Command, fmt.Sprintf("invalid value for --%s: %q", name, value)}
+	}
+	return n, nil
+}
+
+// IsSet returns true if the option was given on the command line
+func (c *Context) IsSet(name string) bool {
+	return c.set[name]
+}
+
+// Set changes the value of an option, as if it had been given on the
+// command line.
+func (c *Context) Set(name, value string) {
+	c.values[name] = value
+	c.set[name] = true
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// findLong returns the option with the long name, or nil
+func findLong(options []*Option, name string) *Option {
+	for _, opt := range options {
+		if opt.Long == name {
+			return opt
+		}
+	}
+	return nil
+}
+
+// checkValue returns an error if the option has a list of possible
+// values that does not include the value. The command is nil for an
+// option of every command.
+func checkValue(cmd *Command, opt *Option, value string) error {
+	if len(opt.Values) == 0 {
+		return nil
+	}
+	for _, v := range opt.Values {
+		if v == value {
+			return nil
+		}
+	}
+	where := ""
+	if cmd != nil {
+		where = " of the " + cmd.Name + " command"
+	}
+	return fmt.Errorf("invalid value %q for --%s%s: expected %s",
+		value, opt.Long, where, strings.Join(opt.Values, ", "))
+}
+
+// findShort returns the option with the short name, or nil
+func findShort(options []*Option, r rune) *Option {
+	for _, opt := range options {
+		if opt.Short != 0 && opt.Short == r {
+			return opt
+		}
+	}
+	return nil
+}
+
+// optionRows returns the name and help text of each option of a command
+// for the help output, e.g., "-g, --game=GAME"
+func (a *App) optionRows(cmd *Command, options []*Option) [][2]string {
+	rows := [][2]string{}
+	for _, opt := range options {
+		name := "    "
+		if opt.Short != 0 {
+			name = fmt.Sprintf("-%c, ", opt.Short)
+		}
+		name += "--" + opt.Long
+		if opt.Arg != "" {
+			name += "=" + opt.Arg
+		}
+		help := opt.Help
+		if def := a.defaultValue(cmd, opt); opt.Arg != "" && def != "" {
+			help += fmt.Sprintf(" (Default is %s)", def)
+		}
+		rows = append(rows, [2]string{name, help})
+	}
+	return rows
+}
+
+// writeRows writes two columns, aligning and wrapping the second. A
+// first column too wide to align puts the second on the next line.
+func writeRows(w io.Writer, rows [][2]string) {
+	const (
+		lineWidth    = 79
+		maxNameWidth = 24
+	)
+	width := 0
+	for _, row := range rows {
+		if n := len([]rune(row[0])); n > width && n <= maxNameWidth {
+			width = n
+		}
+	}
+	indent := strings.Repeat(" ", width+4)
+	for _, row := range rows {
+		lines := wrap(row[1], lineWidth-len(indent))
+		name := row[0]
+		if n := len([]rune(name)); n > width {
+			fmt.Fprintf(w, "  %s\n", name)
+		} else if len(lines) > 0 {
+			fmt.Fprintf(w, "  %s%s  %s\n", name, strings.Repeat(" ", width-n), lines[0])
+			lines = lines[1:]
+		} else {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+		for _, line := range lines {
+			fmt.Fprintf(w, "%s%s\n", indent, line)
+		}
+	}
+}
+
+// wrap splits text into lines of no more than width characters,
+// breaking at spaces
+func wrap(text string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestApp() *App {
+	return &App{
+		Name:    "test",
+		Summary: "A test program",
+		Default: "show",
+		Options: []*Option{
+			{Long: "color", Arg: "WHEN", Default: "auto", Help: "Color mode"},
+			{Long: "verbose", Short: 'v', Help: "Be verbose"},
+		},
+		Commands: []*Command{
+			{
+				Name: "show",
+				Args: "[GAME]",
+				Options: []*Option{
+					{Long: "game", Short: 'g', Arg: "GAME", Help: "Game name"},
+					{Long: "list", Short: 'l', Help: "List games"},
+				},
+			},
+			{Name: "table", Summary: "Show a table"},
+		},
+		Stdout: &bytes.Buffer{},
+	}
+}
+
+func TestApp_Parse(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expectedCmd  string
+		expectedArgs []string
+		expectedOpts map[string]string
+	}{
+		{"default", []string{}, "show", nil,
+			map[string]string{"color": "auto", "game": "", "list": ""}},
+		{"long with equals", []string{"--game=spider"}, "show", nil,
+			map[string]string{"game": "spider"}},
+		{"long with value", []string{"--game", "spider"}, "show", nil,
+			map[string]string{"game": "spider"}},
+		{"short with value", []string{"-g", "spider"}, "show", nil,
+			map[string]string{"game": "spider"}},
+		{"short attached", []string{"-gspider"}, "show", nil,
+			map[string]string{"game": "spider"}},
+		{"combined flags", []string{"-vl"}, "show", nil,
+			map[string]string{"verbose": "true", "list": "true"}},
+		{"combined with value", []string{"-vlg", "spider"}, "show", nil,
+			map[string]string{"verbose": "true", "list": "true", "game": "spider"}},
+		{"named command", []string{"table"}, "table", nil,
+			map[string]string{"color": "auto"}},
+		{"options before command", []string{"--color", "never", "table"}, "table", nil,
+			map[string]string{"color": "never"}},
+		{"options after command", []string{"table", "--color=never"}, "table", nil,
+			map[string]string{"color": "never"}},
+		{"value looks like command", []string{"-g", "table"}, "show", nil,
+			map[string]string{"game": "table"}},
+		{"positional", []string{"spider", "-v"}, "show", []string{"spider"},
+			map[string]string{"verbose": "true"}},
+		{"double dash", []string{"show", "--", "-v"}, "show", []string{"-v"},
+			map[string]string{"verbose": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := newTestApp().Parse(tt.args)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedCmd, ctx.Command.Name)
+			assert.Equal(t, tt.expectedArgs, ctx.Args)
+			for name, value := range tt.expectedOpts {
+				assert.Equal(t, value, ctx.String(name), name)
+			}
+		})
+	}
+}
+
+func TestApp_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"unknown long", []string{"--bogus"}, "unknown option --bogus"},
+		{"unknown short", []string{"-x"}, "unknown option -x"},
+		{"missing value", []string{"--game"}, "option --game requires a value"},
+		{"missing short value", []string{"-g"}, "option -g requires a value"},
+		{"flag with value", []string{"--list=yes"}, "option --list does not take a value"},
+		{"other command's option", []string{"table", "--game=spider"}, "unknown option --game"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestApp().Parse(tt.args)
+			assert.NotNil(t, err)
+			var usageErr *UsageError
+			assert.ErrorAs(t, err, &usageErr)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestApp_ParseHelp(t *testing.T) {
+	app := newTestApp()
+	_, err := app.Parse([]string{"--help"})
+	assert.Equal(t, ErrHelp, err)
+	help := app.Stdout.(*bytes.Buffer).String()
+	assert.Contains(t, help, "Usage: test [COMMAND]")
+	assert.Contains(t, help, "show   (default)")
+	assert.Contains(t, help, "-g, --game=GAME")
+	assert.Contains(t, help, "--color=WHEN")
+
+	app = newTestApp()
+	_, err = app.Parse([]string{"table", "-h"})
+	assert.Equal(t, ErrHelp, err)
+	help = app.Stdout.(*bytes.Buffer).String()
+	assert.Contains(t, help, "Usage: test table [OPTION]...")
+	assert.NotContains(t, help, "--game")
+}
+
+func TestContext_Int(t *testing.T) {
+	app := newTestApp()
+	app.Options = append(app.Options, &Option{Long: "count", Arg: "N", Default: "3"})
+	ctx, err := app.Parse([]string{})
+	assert.Nil(t, err)
+	n, err := ctx.Int("count")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+
+	ctx, err = app.Parse([]string{"--count=x"})
+	assert.Nil(t, err)
+	_, err = ctx.Int("count")
+	assert.NotNil(t, err)
+}
+
+func Test_wrap(t *testing.T) {
+	assert.Equal(t, []string{"one two", "three"}, wrap("one two three", 9))
+	assert.Equal(t, []string{}, wrap("", 9))
+}
+
+func TestApp_ParseVersion(t *testing.T) {
+	app := newTestApp()
+	_, err := app.Parse([]string{"--version"})
+	var usageErr *UsageError
+	assert.ErrorAs(t, err, &usageErr)
+
+	app = newTestApp()
+	app.Version, app.Build = "v1.2.3", "(go1.22)"
+	_, err = app.Parse([]string{"table", "--version"})
+	assert.Equal(t, ErrVersion, err)
+	assert.Equal(t, "test v1.2.3 (go1.22)\n", app.Stdout.(*bytes.Buffer).String())
+}
+
+func TestApp_SetDefault(t *testing.T) {
+	app := newTestApp()
+	assert.Nil(t, app.SetDefault("game", "spider"))
+	assert.Nil(t, app.SetDefault("color", "never"))
+	assert.NotNil(t, app.SetDefault("bogus", "x"))
+
+	ctx, err := app.Parse([]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "spider", ctx.String("game"))
+	assert.False(t, ctx.IsSet("game"))
+	assert.Equal(t, "never", ctx.String("color"))
+
+	ctx, err = app.Parse([]string{"-g", "freecell"})
+	assert.Nil(t, err)
+	assert.Equal(t, "freecell", ctx.String("game"))
+
+	// The help shows the new default, but the man page does not
+	_, err = app.Parse([]string{"--help"})
+	assert.ErrorIs(t, err, ErrHelp)
+	assert.Contains(t, app.Stdout.(*bytes.Buffer).String(), "(Default is never)")
+	var buf bytes.Buffer
+	app.WriteMan(&buf, time.Now())
+	assert.Contains(t, buf.String(), "(Default is auto)")
+	assert.NotContains(t, buf.String(), "never")
+}
+
+func TestApp_SetCommandDefault(t *testing.T) {
+	app := newTestApp()
+	app.Lookup("show").Options = append(app.Lookup("show").Options,
+		&Option{Long: "format", Arg: "FORMAT", Default: "text", Values: []string{"text", "json"}})
+	app.Lookup("table").Options = append(app.Lookup("table").Options,
+		&Option{Long: "format", Arg: "FORMAT", Default: "text", Values: []string{"text", "markdown"}})
+
+	// A default for every command must suit every command
+	assert.ErrorContains(t, app.SetDefault("format", "markdown"), `invalid value "markdown" for --format of the show command`)
+	assert.Nil(t, app.SetDefault("format", "text"))
+
+	assert.Nil(t, app.SetCommandDefault("table", "format", "markdown"))
+	assert.Nil(t, app.SetCommandDefault("table", "color", "never"))
+	assert.ErrorContains(t, app.SetCommandDefault("show", "format", "markdown"), "invalid value")
+	assert.ErrorContains(t, app.SetCommandDefault("bogus", "format", "text"), "unknown command")
+	assert.ErrorContains(t, app.SetCommandDefault("show", "list2", "x"), "unknown option")
+
+	ctx, err := app.Parse([]string{"table"})
+	assert.Nil(t, err)
+	assert.Equal(t, "markdown", ctx.String("format"))
+	assert.Equal(t, "never", ctx.String("color"))
+	ctx, err = app.Parse([]string{"show"})
+	assert.Nil(t, err)
+	assert.Equal(t, "text", ctx.String("format"))
+	assert.Equal(t, "auto", ctx.String("color"))
+}
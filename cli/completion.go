@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Shells for which completion scripts can be written
+var Shells = []string{"bash", "zsh", "fish"}
+
+// bashFiles is the word the bash script uses to mean "complete a file"
+const bashFiles = "__files__"
+
+// WriteCompletion writes a completion script for the shell, which is
+// one of Shells. Commands and options are completed from the App
+// definition. Values with a Dynamic kind are completed by running the
+// program's CompleteCommand.
+func (a *App) WriteCompletion(w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		a.writeBash(w)
+	case "zsh":
+		a.writeZsh(w)
+	case "fish":
+		a.writeFish(w)
+	default:
+		return fmt.Errorf("unsupported shell %q: expected one of %s",
+			shell, strings.Join(Shells, ", "))
+	}
+	return nil
+}
+
+// writeBash writes a bash completion script
+func (a *App) writeBash(w io.Writer) {
+	fn := "_" + shellName(a.Name)
+	fmt.Fprintf(w, "# bash completion for %s\n", a.Name)
+	fmt.Fprintf(w, "# Load with: source <(%s completion bash)\n\n", a.Name)
+
+	// Values for each option that takes one
+	fmt.Fprintf(w, "%s_values() {\n", fn)
+	fmt.Fprintf(w, "    case \"$1\" in\n")
+	for _, opt := range a.allOptions() {
+		if words := a.bashWords(opt); words != "" {
+			fmt.Fprintf(w, "        %s) %s ;;\n", strings.Join(optionNames(opt), "|"), words)
+		}
+	}
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintf(w, "    local cur prev cmd opts args words i\n")
+	fmt.Fprintf(w, "    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(w, "    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(w, "    cmd=\"\"\n")
+	fmt.Fprintf(w, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(w, "        case \"${COMP_WORDS[i]}\" in\n")
+	fmt.Fprintf(w, "            %s) cmd=\"${COMP_WORDS[i]}\"; break ;;\n", strings.Join(a.CommandNames(), "|"))
+	fmt.Fprintf(w, "        esac\n")
+	fmt.Fprintf(w, "    done\n\n")
+
+	// Options and argument values for the command
+	fmt.Fprintf(w, "    case \"$cmd\" in\n")
+	for _, cmd := range a.visibleCommands() {
+		pattern := cmd.Name
+		if cmd.Name == a.Default {
+			pattern += "|\"\""
+		}
+		fmt.Fprintf(w, "        %s)\n", pattern)
+		fmt.Fprintf(w, "            opts=%q\n", strings.Join(allOptionNames(a.optionsFor(cmd)), " "))
+		fmt.Fprintf(w, "            args=%q\n", cmd.Dynamic)
+		fmt.Fprintf(w, "            ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	// Complete the value of an option given as "--opt value" or
+	// "--opt=value". Bash splits the latter at the "=".
+	fmt.Fprintf(w, "    if [[ \"$prev\" == \"=\" ]]; then\n")
+	fmt.Fprintf(w, "        prev=\"${COMP_WORDS[COMP_CWORD-2]}\"\n")
+	fmt.Fprintf(w, "    elif [[ \"$cur\" == \"=\" ]]; then\n")
+	fmt.Fprintf(w, "        cur=\"\"\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    if [[ \"$prev\" == -* ]]; then\n")
+	fmt.Fprintf(w, "        words=\"$(%s_values \"$prev\")\"\n", fn)
+	fmt.Fprintf(w, "        if [[ \"$words\" == %s ]]; then\n", bashFiles)
+	fmt.Fprintf(w, "            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	fmt.Fprintf(w, "            return\n")
+	fmt.Fprintf(w, "        elif [[ -n \"$words\" ]]; then\n")
+	fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "            return\n")
+	fmt.Fprintf(w, "        fi\n")
+	fmt.Fprintf(w, "    fi\n\n")
+
+	// Complete options, then commands and arguments
+	fmt.Fprintf(w, "    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$opts\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "        return\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    words=\"\"\n")
+	fmt.Fprintf(w, "    if [[ -z \"$cmd\" ]]; then\n")
+	fmt.Fprintf(w, "        words=%q\n", strings.Join(a.CommandNames(), " "))
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    if [[ -n \"$args\" ]]; then\n")
+	fmt.Fprintf(w, "        words=\"$words $(%s %s \"$args\" 2>/dev/null)\"\n", a.Name, a.CompleteCommand)
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "complete -F %s %s\n", fn, a.Name)
+}
+
+// writeZsh writes a zsh completion script
+func (a *App) writeZsh(w io.Writer) {
+	fn := "_" + shellName(a.Name)
+	fmt.Fprintf(w, "#compdef %s\n", a.Name)
+	fmt.Fprintf(w, "# zsh completion for %s\n", a.Name)
+	fmt.Fprintf(w, "# Load with: source <(%s completion zsh)\n\n", a.Name)
+
+	// Values for each option that takes one
+	fmt.Fprintf(w, "%s_values() {\n", fn)
+	fmt.Fprintf(w, "    local -a values\n")
+	fmt.Fprintf(w, "    case $1 in\n")
+	for _, opt := range a.allOptions() {
+		switch {
+		case opt.Dynamic != "":
+			fmt.Fprintf(w, "        (%s) values=(${(f)\"$(%s %s %s 2>/dev/null)\"}) ;;\n",
+				strings.Join(optionNames(opt), "|"), a.Name, a.CompleteCommand, opt.Dynamic)
+		case len(opt.Values) > 0:
+			fmt.Fprintf(w, "        (%s) values=(%s) ;;\n",
+				strings.Join(optionNames(opt), "|"), strings.Join(opt.Values, " "))
+		case opt.Arg == "FILE":
+			fmt.Fprintf(w, "        (%s) _files; return 0 ;;\n", strings.Join(optionNames(opt), "|"))
+		}
+	}
+	fmt.Fprintf(w, "        (*) return 1 ;;\n")
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "    compadd -a values\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintf(w, "    local cmd i args\n")
+	fmt.Fprintf(w, "    local -a commands opts\n")
+	fmt.Fprintf(w, "    commands=(\n")
+	for _, cmd := range a.visibleCommands() {
+		summary := strings.ReplaceAll(cmd.Summary, ":", `\:`)
+		fmt.Fprintf(w, "        %s\n", zshQuote(cmd.Name+":"+summary))
+	}
+	fmt.Fprintf(w, "    )\n")
+	fmt.Fprintf(w, "    for ((i = 2; i < CURRENT; i++)); do\n")
+	fmt.Fprintf(w, "        case ${words[i]} in\n")
+	fmt.Fprintf(w, "            (%s) cmd=${words[i]}; break ;;\n", strings.Join(a.CommandNames(), "|"))
+	fmt.Fprintf(w, "        esac\n")
+	fmt.Fprintf(w, "    done\n\n")
+
+	fmt.Fprintf(w, "    case $cmd in\n")
+	for _, cmd := range a.visibleCommands() {
+		pattern := cmd.Name
+		if cmd.Name == a.Default {
+			pattern += "|"
+		}
+		fmt.Fprintf(w, "        (%s)\n", pattern)
+		fmt.Fprintf(w, "            opts=(%s)\n", strings.Join(allOptionNames(a.optionsFor(cmd)), " "))
+		fmt.Fprintf(w, "            args=%s\n", zshQuote(cmd.Dynamic))
+		fmt.Fprintf(w, "            ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	// Complete the value of an option given as "--opt=value" or
+	// "--opt value"
+	fmt.Fprintf(w, "    if [[ ${words[CURRENT]} == --*=* ]]; then\n")
+	fmt.Fprintf(w, "        local opt=${words[CURRENT]%%%%=*}\n")
+	fmt.Fprintf(w, "        compset -P '*='\n")
+	fmt.Fprintf(w, "        %s_values $opt\n", fn)
+	fmt.Fprintf(w, "        return\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    if [[ ${words[CURRENT-1]} == -* ]] && %s_values ${words[CURRENT-1]}; then\n", fn)
+	fmt.Fprintf(w, "        return\n")
+	fmt.Fprintf(w, "    fi\n\n")
+
+	// Complete options, then commands and arguments
+	fmt.Fprintf(w, "    if [[ ${words[CURRENT]} == -* ]]; then\n")
+	fmt.Fprintf(w, "        compadd -a opts\n")
+	fmt.Fprintf(w, "        return\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    if [[ -z $cmd ]]; then\n")
+	fmt.Fprintf(w, "        _describe 'command' commands\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "    if [[ -n $args ]]; then\n")
+	fmt.Fprintf(w, "        local -a values\n")
+	fmt.Fprintf(w, "        values=(${(f)\"$(%s %s $args 2>/dev/null)\"})\n", a.Name, a.CompleteCommand)
+	fmt.Fprintf(w, "        compadd -a values\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "compdef %s %s\n", fn, a.Name)
+}
+
+// writeFish writes a fish completion script
+func (a *App) writeFish(w io.Writer) {
+	name := a.Name
+	commands := strings.Join(a.CommandNames(), " ")
+	fmt.Fprintf(w, "# fish completion for %s\n", name)
+	fmt.Fprintf(w, "# Load with: %s completion fish | source\n\n", name)
+	fmt.Fprintf(w, "complete -c %s -f\n\n", name)
+
+	// Commands
+	for _, cmd := range a.visibleCommands() {
+		fmt.Fprintf(w, "complete -c %s -n 'not __fish_seen_subcommand_from %s' -a %s -d %s\n",
+			name, commands, cmd.Name, fishQuote(cmd.Summary))
+	}
+	fmt.Fprintln(w)
+
+	// Options for all commands
+	for _, opt := range a.globalOptions() {
+		fmt.Fprintf(w, "complete -c %s%s\n", name, a.fishOption(opt))
+	}
+
+	// Options and arguments for each command. The default command's
+	// also apply when no command has been typed.
+	for _, cmd := range a.visibleCommands() {
+		condition := fmt.Sprintf("__fish_seen_subcommand_from %s", cmd.Name)
+		if cmd.Name == a.Default {
+			condition = fmt.Sprintf("not __fish_seen_subcommand_from %s; or %s", commands, condition)
+		}
+		for _, opt := range cmd.Options {
+			fmt.Fprintf(w, "complete -c %s -n %s%s\n", name, fishQuote(condition), a.fishOption(opt))
+		}
+		if cmd.Dynamic != "" {
+			fmt.Fprintf(w, "complete -c %s -n %s -a %s\n", name, fishQuote(condition),
+				fishQuote(fmt.Sprintf("(%s %s %s 2>/dev/null)", name, a.CompleteCommand, cmd.Dynamic)))
+		}
+	}
+}
+
+// bashWords returns the shell code that lists the possible values of
+// an option. Options whose value is a FILE are completed as files.
+func (a *App) bashWords(opt *Option) string {
+	switch {
+	case opt.Dynamic != "":
+		return fmt.Sprintf("%s %s %s 2>/dev/null", a.Name, a.CompleteCommand, opt.Dynamic)
+	case len(opt.Values) > 0:
+		return fmt.Sprintf("echo %q", strings.Join(opt.Values, " "))
+	case opt.Arg == "FILE":
+		return "echo " + bashFiles
+	}
+	return ""
+}
+
+// fishOption returns the arguments of a fish complete command that
+// describe the option
+func (a *App) fishOption(opt *Option) string {
+	var sb strings.Builder
+	if opt.Short != 0 {
+		fmt.Fprintf(&sb, " -s %c", opt.Short)
+	}
+	fmt.Fprintf(&sb, " -l %s", opt.Long)
+	switch {
+	case opt.Dynamic != "":
+		fmt.Fprintf(&sb, " -x -a %s", fishQuote(fmt.Sprintf("(%s %s %s 2>/dev/null)", a.Name, a.CompleteCommand, opt.Dynamic)))
+	case len(opt.Values) > 0:
+		fmt.Fprintf(&sb, " -x -a %s", fishQuote(strings.Join(opt.Values, " ")))
+	case opt.Arg == "FILE":
+		sb.WriteString(" -r -F")
+	case opt.Arg != "":
+		sb.WriteString(" -x")
+	}
+	fmt.Fprintf(&sb, " -d %s", fishQuote(opt.Help))
+	return sb.String()
+}
+
+// allOptions returns every option of the program, without duplicates
+func (a *App) allOptions() []*Option {
+	options := a.globalOptions()
+	seen := map[string]bool{}
+	for _, opt := range options {
+		seen[opt.Long] = true
+	}
+	for _, cmd := range a.visibleCommands() {
+		for _, opt := range cmd.Options {
+			if !seen[opt.Long] {
+				seen[opt.Long] = true
+				options = append(options, opt)
+			}
+		}
+	}
+	return options
+}
+
+// optionNames returns the forms of an option, e.g., "--game" and "-g"
+func optionNames(opt *Option) []string {
+	names := []string{"--" + opt.Long}
+	if opt.Short != 0 {
+		names = append(names, fmt.Sprintf("-%c", opt.Short))
+	}
+	return names
+}
+
+// allOptionNames returns the forms of all the options
+func allOptionNames(options []*Option) []string {
+	names := []string{}
+	for _, opt := range options {
+		names = append(names, optionNames(opt)...)
+	}
+	return names
+}
+
+// shellName converts the program name to a valid shell function name
+func shellName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// zshQuote quotes a string for zsh
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes a string for fish
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
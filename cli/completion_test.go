@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompletionApp() *App {
+	app := newTestApp()
+	app.CompleteCommand = "__complete"
+	app.Options[0].Values = []string{"auto", "always", "never"}
+	app.Commands[0].Options[0].Dynamic = "games"
+	app.Commands[0].Dynamic = "games"
+	app.Commands = append(app.Commands, &Command{Name: "__complete", Hidden: true})
+	return app
+}
+
+func TestApp_WriteCompletion(t *testing.T) {
+	tests := []struct {
+		shell    string
+		expected []string
+	}{
+		{"bash", []string{
+			"complete -F _test test",
+			"--color) echo \"auto always never\" ;;",
+			"--game|-g) test __complete games 2>/dev/null ;;",
+			"show|table) cmd=",
+			"show|\"\")",
+		}},
+		{"zsh", []string{
+			"#compdef test",
+			"(--color) values=(auto always never) ;;",
+			"'table:Show a table'",
+			"compdef _test test",
+		}},
+		{"fish", []string{
+			"complete -c test -l color -x -a 'auto always never' -d 'Color mode'",
+			"-s g -l game -x -a '(test __complete games 2>/dev/null)'",
+			"-a table -d 'Show a table'",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := newCompletionApp().WriteCompletion(&buf, tt.shell)
+			assert.Nil(t, err)
+			script := buf.String()
+			for _, expected := range tt.expected {
+				assert.Contains(t, script, expected)
+			}
+			assert.NotContains(t, script, "-a __complete")
+		})
+	}
+}
+
+func TestApp_WriteCompletionBadShell(t *testing.T) {
+	var buf bytes.Buffer
+	err := newCompletionApp().WriteCompletion(&buf, "csh")
+	assert.NotNil(t, err)
+}
+
+func TestApp_CommandNamesHidden(t *testing.T) {
+	assert.Equal(t, []string{"show", "table"}, newCompletionApp().CommandNames())
+}
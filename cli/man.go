@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ManSection is an extra section of a man page, such as FILES
+type ManSection struct {
+	Title string
+	Text  string
+}
+
+// WriteMan writes a man page in roff format for section 1 of the
+// manual. The synopsis, commands, and options come from the App
+// definition, followed by the extra sections.
+func (a *App) WriteMan(w io.Writer, date time.Time, extra ...ManSection) {
+	name := a.Name
+	fmt.Fprintf(w, ".TH %s 1 %q %q \"User Commands\"\n",
+		strings.ToUpper(name), date.Format("2006-01-02"), strings.TrimSpace(name+" "+a.Version))
+
+	fmt.Fprintf(w, ".SH NAME\n")
+	fmt.Fprintf(w, "%s \\- %s\n", name, roffEscape(strings.TrimSuffix(a.Summary, ".")))
+
+	fmt.Fprintf(w, ".SH SYNOPSIS\n")
+	for _, cmd := range a.visibleCommands() {
+		fmt.Fprintf(w, ".B %s\n", name)
+		synopsis := cmd.Name + " [OPTION]..."
+		if cmd.Args != "" {
+			synopsis += " " + cmd.Args
+		}
+		fmt.Fprintf(w, "%s\n.br\n", roffEscape(synopsis))
+	}
+
+	fmt.Fprintf(w, ".SH DESCRIPTION\n")
+	fmt.Fprintf(w, "%s\n", roffText(a.Summary))
+	if a.Default != "" {
+		fmt.Fprintf(w, ".PP\nWith no command, \\fB%s\\fR runs \\fB%s\\fR.\n", name, a.Default)
+	}
+	if a.Help != "" {
+		fmt.Fprintf(w, ".PP\n%s\n", roffText(a.Help))
+	}
+
+	fmt.Fprintf(w, ".SH COMMANDS\n")
+	for _, cmd := range a.visibleCommands() {
+		fmt.Fprintf(w, ".TP\n\\fB%s\\fR", cmd.Name)
+		if cmd.Args != "" {
+			fmt.Fprintf(w, " \\fI%s\\fR", roffEscape(cmd.Args))
+		}
+		fmt.Fprintf(w, "\n%s\n", roffText(cmd.Summary))
+		if cmd.Help != "" {
+			fmt.Fprintf(w, ".IP\n%s\n", roffText(cmd.Help))
+		}
+		if len(cmd.Options) > 0 {
+			fmt.Fprintf(w, ".RS\n")
+			writeManOptions(w, cmd.Options)
+			fmt.Fprintf(w, ".RE\n")
+		}
+	}
+
+	fmt.Fprintf(w, ".SH OPTIONS\n")
+	fmt.Fprintf(w, "These options are accepted by every command.\n")
+	writeManOptions(w, a.globalOptions())
+
+	for _, section := range extra {
+		fmt.Fprintf(w, ".SH %s\n", strings.ToUpper(section.Title))
+		fmt.Fprintf(w, "%s\n", roffText(section.Text))
+	}
+}
+
+// writeManOptions writes a tagged paragraph for each option
+func writeManOptions(w io.Writer, options []*Option) {
+	for _, opt := range options {
+		fmt.Fprintf(w, ".TP\n")
+		if opt.Short != 0 {
+			fmt.Fprintf(w, "\\fB\\-%c\\fR, ", opt.Short)
+		}
+		fmt.Fprintf(w, "\\fB\\-\\-%s\\fR", roffEscape(opt.Long))
+		if opt.Arg != "" {
+			fmt.Fprintf(w, "=\\fI%s\\fR", roffEscape(opt.Arg))
+		}
+		help := opt.Help
+		if opt.Arg != "" && opt.Default != "" {
+			help += fmt.Sprintf(" (Default is %s)", opt.Default)
+		}
+		fmt.Fprintf(w, "\n%s\n", roffText(help))
+	}
+}
+
+// roffText converts plain text to roff. Blank lines become paragraph
+// breaks, and runs of indented lines are shown as they are.
+func roffText(text string) string {
+	lines := []string{}
+	literal := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		indented := strings.HasPrefix(line, " ")
+		if indented != literal {
+			if indented {
+				lines = append(lines, ".nf")
+			} else {
+				lines = append(lines, ".fi")
+			}
+			literal = indented
+		}
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, ".PP")
+		} else {
+			lines = append(lines, roffEscape(line))
+		}
+	}
+	if literal {
+		lines = append(lines, ".fi")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffEscape escapes backslashes, hyphens, and leading control
+// characters
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_WriteMan(t *testing.T) {
+	app := newTestApp()
+	app.Version = "v1.2.3"
+	var buf bytes.Buffer
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	app.WriteMan(&buf, date, ManSection{Title: "Files", Text: "~/.testrc"})
+	man := buf.String()
+	assert.Contains(t, man, `.TH TEST 1 "2024-03-01" "test v1.2.3" "User Commands"`)
+	assert.Contains(t, man, "test \\- A test program\n")
+	assert.Contains(t, man, "show [OPTION]... [GAME]")
+	assert.Contains(t, man, "\\fB\\-g\\fR, \\fB\\-\\-game\\fR=\\fIGAME\\fR")
+	assert.Contains(t, man, "\\fB\\-\\-version\\fR")
+	assert.Contains(t, man, ".SH FILES\n~/.testrc\n")
+}
+
+func Test_roffText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"plain", "one\ntwo", "one\ntwo"},
+		{"paragraphs", "one\n\ntwo", "one\n.PP\ntwo"},
+		{"literal", "run:\n  a -b\n  c\ndone", "run:\n.nf\n  a \\-b\n  c\n.fi\ndone"},
+		{"literal at end", "run:\n  a", "run:\n.nf\n  a\n.fi"},
+		{"control character", ".dot", "\\&.dot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, roffText(tt.text))
+		})
+	}
+}
@@ -1,79 +1,520 @@
 package main
 
 import (
-	"flag"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
-	"github.com/philhanna/aisleriot/model"
+	"github.com/philhanna/aisleriot/cli"
 	"github.com/philhanna/aisleriot/view"
 )
 
+// app is the definition of every command and option. The help text is
+// generated from it.
+var app = &cli.App{
+	Name:    "arstats",
+	Summary: "Shows statistics for Aisleriot games played by the current user.",
+	Default: "show",
+
+	CompleteCommand: "__complete",
+	Options: []*cli.Option{
+		{Long: "file", Arg: "FILE",
+			Help: "Read the statistics from FILE instead of " +
+				"~/.config/gnome-games/aisleriot, or from standard input if FILE is -"},
+		{Long: "user", Arg: "NAME", Dynamic: "users",
+			Help: "Use the statistics, history, and achievements of user NAME, from their home directory, without writing to it"},
+		{Long: "history", Arg: "FILE",
+			Help: "Keep the snapshot history in FILE instead of " +
+				"~/.local/share/arstats/history"},
+		{Long: "color", Arg: "WHEN", Default: "auto",
+			Values: []string{"auto", "always", "never"},
+			Help: "Color the output: always, never, or auto, which colors only " +
+				"when writing to a terminal and NO_COLOR is not set"},
+		{Long: "color-threshold", Arg: "PCT", Default: "50",
+			Help: "Show winning percentages at or above PCT in green and below it in red"},
+		{Long: "precision", Arg: "N", Default: "0",
+			Help: "Show winning percentages with N decimal places"},
+		{Long: "lang", Arg: "LANGUAGE",
+			Values: []string{"en", "de", "fr", "es"},
+			Help: "Language for labels and numbers: en, de, fr, or es. " +
+				"If not given, it is taken from LC_ALL, LC_MESSAGES, or LANG. " +
+				"Game names are not translated"},
+		{Long: "time-format", Arg: "FORMAT", Default: "clock",
+			Values: []string{"clock", "iso", "compact", "seconds"},
+			Help: "Format for times: clock (mm:ss or h:mm:ss), iso (PT1H2M7S), " +
+				"compact (1h2m7s), or seconds"},
+	},
+	Commands: []*cli.Command{
+		{
+			Name:    "show",
+			Args:    "[GAME]",
+			Summary: "Show the statistics for one game",
+			Help: `
+The game is given with --game or as an argument. The default is the most
+recently played game.
+
+Output includes the game name, the number of wins, losses, and total
+games played, the best, average, and worst times, the winning
+percentage, and the number of wins to the next higher percent and losses
+to the next lower percent. Times are shown as N/A when no game has been
+won. A game in the recent list with no statistics is marked "no
+statistics".
+
+Templates are given .Name, .Section, .Options, .HasStats, .Stats, and
+.History, which has the .Time and .Stats of the game in each snapshot,
+and may use the helper functions duration, number, percent, plural,
+time, and tr.
+Use time rather than duration for best, average, and worst times, since
+it shows N/A for a game that has never been won, e.g.:
+  --template-string='{{.Name}}: {{percent .Stats.Percentage}}'
+  --template-string='{{.Name}}: {{time .Stats .Stats.Best}}'`,
+			Options: []*cli.Option{
+				gameOption,
+				{Long: "format", Arg: "FORMAT", Default: "text",
+					Values: []string{"text", "json"},
+					Help:   "Output format: text or json"},
+				{Long: "list", Short: 'l', Help: "List the names of all games played (same as the list command)"},
+				{Long: "all", Short: 'a', Help: "With --list, also list the games that are not in the recent list"},
+				{Long: "template", Arg: "FILE", Help: "Format the output with the Go text/template in FILE"},
+				{Long: "template-string", Arg: "TEXT", Help: "Format the output with the Go text/template given as TEXT"},
+			},
+			Run:     runShow,
+			Dynamic: "games",
+		},
+		{
+			Name:    "list",
+			Summary: "List the names of all games played, most recent first",
+			Help: `
+Only the games in Aisleriot's list of recent games are listed. With
+--all, the other games with statistics are listed after them, marked
+"not recently played", and games in the recent list that have no
+statistics are marked "no statistics".`,
+			Options: []*cli.Option{
+				{Long: "all", Short: 'a', Help: "Also list the games that are not in the recent list"},
+			},
+			Run: runList,
+		},
+		{
+			Name:    "table",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Show the statistics for all games, or the ones given, in a table",
+			Run:     runTable,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "goal",
+			Args:    "PCT [GAME]",
+			Summary: "Show the number of wins in a row needed to reach a winning percentage",
+			Options: []*cli.Option{gameOption},
+			Run:     runGoal,
+			Dynamic: "games",
+		},
+		{
+			Name:    "export",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Write the statistics for all games, or the ones given, as JSON",
+			Help: `
+With --share, the statistics are written in the form read by the
+leaderboard command, with your player name. If --key is given, the file
+is signed with the key in FILE, which the players share, so that the
+leaderboard can check that it was not edited.`,
+			Options: []*cli.Option{
+				{Long: "share",
+					Help: "Write the statistics for the leaderboard command"},
+				{Long: "player", Arg: "NAME",
+					Help: "Player name for --share. If not given, your user name is used"},
+				{Long: "key", Arg: "FILE",
+					Help: "Sign the --share output with the key in FILE"},
+			},
+			Run:     runExport,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "check",
+			Summary: "Check that the statistics of every game are possible",
+			Help: `
+Every game's statistics are checked for values that Aisleriot could not
+have written, such as negative numbers, more wins than games, or a best
+time longer than the worst. Each problem is printed, and the exit status
+is then non-zero, so that this can be run from cron, e.g., after
+restoring a backup.`,
+			Options: []*cli.Option{
+				{Long: "quiet", Short: 'q', Help: "Print nothing if there are no problems"},
+			},
+			Run: runCheck,
+		},
+		{
+			Name:    "repair",
+			Args:    "[FILE]",
+			Summary: "Repair a damaged statistics file",
+			Help: `
+Every section that can be read is kept. Lines that cannot be read are
+removed, whitespace and a missing final ";" are fixed in Statistic
+lines, and the list of recent games is rebuilt if it is missing. The
+changes are shown as a diff, and the original file is kept as FILE.bak,
+or FILE.bak.N if that exists. FILE is the statistics file if not given,
+and cannot be compressed or in an archive.
+
+Statistics that can be read but are impossible, such as more wins than
+games, are not changed. They are shown, and the exit status is
+non-zero.`,
+			Options: []*cli.Option{
+				{Long: "dry-run", Help: "Show the changes without writing the file"},
+			},
+			Run: runRepair,
+		},
+		{
+			Name:    "snapshot",
+			Summary: "Add the current statistics to the history",
+			Help: `
+A snapshot is added only if the statistics have changed since the last
+one. The history is used by the streaks, eras, report, and calendar
+commands. Run this from cron, or use the watch command to record a
+snapshot after every game.`,
+			Run: runSnapshot,
+		},
+		{
+			Name:    "import-history",
+			Args:    "FILE...",
+			Summary: "Add snapshots of old copies of the statistics file to the history",
+			Help: `
+Each FILE is a backup of the statistics file, which may be compressed or
+in an archive (ARCHIVE#MEMBER), or a JSON export. Its snapshot is dated
+by a date in its name, e.g., aisleriot-2024-03-01.gz or
+aisleriot.20240301T2015, or else by the time the file was written, as
+chosen by --time. Files are imported in time order. A game whose
+statistics went down since the snapshot before was reset, and this is
+recorded in the history. Files that cannot be read or dated are
+rejected, and the exit status is then non-zero.`,
+			Options: []*cli.Option{
+				{Long: "time", Arg: "FROM", Default: "auto",
+					Values: []string{"auto", "name", "mtime"},
+					Help:   "Date snapshots from the file name, the file time (mtime), or the name if it has a date (auto)"},
+				{Long: "dry-run",
+					Help: "Show what would be imported without changing the history"},
+			},
+			Run: runImportHistory,
+		},
+		{
+			Name:    "watch",
+			Summary: "Add a snapshot to the history whenever the statistics change",
+			Help: `
+New best times, higher winning percentages, every 100 games played, and
+newly unlocked achievements are printed, and can also be shown as
+desktop notifications.`,
+			Options: []*cli.Option{
+				{Long: "interval", Arg: "SECONDS", Default: "5",
+					Help: "How often to check the statistics file"},
+				{Long: "notify", Arg: "METHOD", Default: "none",
+					Values: view.NotifyMethods,
+					Help: "Send desktop notifications with notify-send, through " +
+						"D-Bus with gdbus, with auto, which uses whichever is " +
+						"installed, or none"},
+			},
+			Run: runWatch,
+		},
+		{
+			Name:    "history",
+			Args:    "[GAME]",
+			Summary: "Show the statistics for a game in each snapshot",
+			Help: `
+Each snapshot in which the game's statistics changed is shown, oldest
+first, and snapshots in which it was reset are marked. The game is the
+most recently played one if not given.`,
+			Options: []*cli.Option{gameOption},
+			Run:     runHistory,
+			Dynamic: "games",
+		},
+		{
+			Name:    "streaks",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Show the current and longest winning and losing streaks",
+			Help: `
+Streaks are found from the snapshot history. When several games were
+played between two snapshots, their order is not known, so a streak is
+shown as at least some number of games.`,
+			Run:     runStreaks,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "eras",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Show the statistics since each reset and for the lifetime of each game",
+			Help: `
+A game was reset when its statistics went down between two snapshots,
+because the statistics were cleared in Aisleriot or the file was
+replaced. Each era runs from one reset to the next, and the lifetime
+totals add up every era. The current statistics are included without
+being added to the history.`,
+			Run:     runEras,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "report",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Show the games played in each day, week, or month",
+			Help: `
+For each period and game, the report shows the games played, won, and
+lost in that period alone, the winning percentage for them, and any new
+best time. It is found from the snapshot history. The games played
+after a game is reset are counted from zero.`,
+			Options: []*cli.Option{
+				{Long: "period", Arg: "PERIOD", Default: "week",
+					Values: []string{"day", "week", "month"},
+					Help:   "Length of each period: day, week, or month"},
+				{Long: "since", Arg: "DATE",
+					Help: "Start the report on DATE, given as YYYY-MM-DD"},
+				{Long: "until", Arg: "DATE",
+					Help: "End the report on DATE, given as YYYY-MM-DD"},
+				{Long: "format", Arg: "FORMAT", Default: "text",
+					Values: view.ReportFormats,
+					Help:   "Output format: text, markdown, or json"},
+			},
+			Run:     runReport,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "calendar",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Show a calendar of the games played on each day",
+			Help: `
+The calendar shows the last year, or the --year, with one column per
+week. Play is grouped into sessions from the snapshot history, and the
+games in a session are counted on the day it started. Darker days have
+more games, more wins, or a higher winning percentage, as chosen by
+--metric.`,
+			Options: []*cli.Option{
+				{Long: "metric", Arg: "METRIC", Default: "games",
+					Values: view.CalendarMetrics,
+					Help:   "What to shade days by: games, wins, or rate"},
+				{Long: "year", Arg: "YEAR",
+					Help: "Show the calendar year YEAR instead of the last year"},
+				{Long: "format", Arg: "FORMAT", Default: "text",
+					Values: []string{"text", "svg"},
+					Help:   "Output format: text or svg"},
+			},
+			Run:     runCalendar,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "achievements",
+			Summary: "Show the achievements and when they were unlocked",
+			Help: `
+Achievements are unlocked when the statistics meet their conditions, and
+are dated from the snapshot history when possible. Add your own in
+~/.config/arstats/achievements, an .ini file with one section per
+achievement, e.g.:
+  [FreeCell master]
+  description = Win 100 FreeCell games
+  game = freecell
+  wins = 100
+The conditions for one game are wins, played, percentage, best-under
+(e.g., 2:00, 2m, or PT2M), and win-streak. They apply to the game, or
+to any game if none is given. The conditions for all games together
+are different-games, total-wins, and total-played.`,
+			Run: runAchievements,
+		},
+		{
+			Name:    "diff",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Compare the statistics with a snapshot, a backup, or an export",
+			Help: `
+Shows what changed in each game since a snapshot in the history, or
+since a copy of the statistics file, with the new values and the change
+in each. --against also accepts the JSON written by the export command.
+Counts that went down are shown in red, because they mean that
+statistics were reset or lost. Without --snapshot or --against, the
+latest snapshot is used.`,
+			Options: []*cli.Option{
+				{Long: "snapshot", Arg: "WHEN",
+					Help: "Compare with the latest snapshot, or the last one before DATE (YYYY-MM-DD)"},
+				{Long: "against", Arg: "FILE",
+					Help: "Compare with a statistics file or an export"},
+			},
+			Run:     runDiff,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "leaderboard",
+			Args:    "DIR [GAME|GROUP]...",
+			Summary: "Rank the players whose shared statistics are in DIR",
+			Help: `
+Reads every .json file in DIR written by 'arstats export --share' and
+ranks the players by winning percentage in all games together and in
+each game, breaking ties by wins and then by best time. If a player has
+several files, the latest is used. If --key is given, files that were
+not signed with it are skipped.`,
+			Options: []*cli.Option{
+				{Long: "min-games", Arg: "N", Default: "10",
+					Help: "Rank a player in a game only after N games of it"},
+				{Long: "min-total", Arg: "N", Default: "50",
+					Help: "Rank a player overall only after N games in all"},
+				{Long: "key", Arg: "FILE",
+					Help: "Check the signatures with the key in FILE"},
+			},
+			Run:     runLeaderboard,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "users",
+			Args:    "[GAME|GROUP]...",
+			Summary: "Compare the statistics of the users of this computer",
+			Help: `
+Finds every user under /home with an Aisleriot statistics file and
+compares their statistics for all games, or for the ones given, taken
+together. Users whose file cannot be read, e.g., because of its
+permissions, are listed after the table. Use --user to see one user's
+statistics with the other commands.`,
+			Run:     runUsers,
+			Dynamic: "games-and-groups",
+		},
+		{
+			Name:    "tui",
+			Summary: "Browse all games in a full-screen terminal interface",
+			Help: `
+Use the arrow keys to move, s to change the sort order, r to reverse it,
+/ to search, and q to quit. The browser is updated when the statistics
+file changes.`,
+			Run: runTUI,
+		},
+		{
+			Name:    "completion",
+			Args:    "SHELL",
+			Summary: "Write a completion script for bash, zsh, or fish",
+			Help: `
+The script completes commands, options, and the names of the games in
+the statistics file. To load it, run one of:
+  source <(arstats completion bash)
+  source <(arstats completion zsh)
+  arstats completion fish | source`,
+			Run:     runCompletion,
+			Dynamic: "shells",
+		},
+		{
+			Name:    "man",
+			Summary: "Write the man page in roff format",
+			Help: `
+To read it, run:
+  arstats man | man -l -`,
+			Run: runMan,
+		},
+		{
+			Name:    "config",
+			Args:    "path",
+			Summary: "Show where the configuration file is",
+			Help: `
+The configuration file is in .ini format. Its [defaults] section gives
+defaults for any long option, which the command line overrides, and a
+[defaults.COMMAND] section gives defaults for one command only. Its
+[aliases] section gives other names for games, and its [groups] section
+names lists of games for the table and export commands, e.g.:
+  [defaults]
+  game = spider
+  time-format = compact
+  [defaults.report]
+  format = markdown
+  [aliases]
+  fc = freecell
+  [groups]
+  patience = klondike, spider, freecell`,
+			Run: runConfig,
+		},
+		{
+			Name:   "__complete",
+			Args:   "KIND",
+			Run:    runComplete,
+			Hidden: true,
+		},
+		{
+			Name:    "help",
+			Args:    "[COMMAND]",
+			Summary: "Show the help for the program or for a command",
+			Run:     runHelp,
+		},
+	},
+}
+
+// gameOption selects a game for the commands that show one
+var gameOption = &cli.Option{
+	Long: "game", Short: 'g', Arg: "GAME", Dynamic: "games",
+	Help: "Name of game for which statistics are desired. " +
+		"If not given, the most recently played game is used",
+}
+
+// manSections are the parts of the man page that are not generated
+// from the commands and options
+var manSections = []cli.ManSection{
+	{Title: "Files", Text: `
+~/.config/gnome-games/aisleriot is the statistics file written by
+Aisleriot. The location follows XDG_CONFIG_HOME if it is set.
+
+A file given with --file may be compressed with gzip or bzip2, or be a
+member of a tar or zip archive, given as ARCHIVE#MEMBER.
+
+~/.config/arstats/config is the arstats configuration file. See the
+config command.
+
+~/.config/arstats/achievements holds your own achievements. See the
+achievements command.
+
+~/.local/share/arstats/history is the snapshot history, and
+~/.local/share/arstats/achievements records when achievements were
+unlocked. The location follows XDG_DATA_HOME if it is set.`},
+	{Title: "Environment", Text: `
+LC_ALL, LC_MESSAGES, and LANG select the language when --lang is not
+given.
+
+NO_COLOR turns off color in auto mode when set to any value.
+
+COLUMNS overrides the terminal width.`},
+	{Title: "Examples", Text: `
+Show the statistics for Spider:
+    arstats -g spider
+Show a one-line summary for a shell prompt:
+    arstats --template-string='{{.Name}} {{percent .Stats.Percentage}}'
+Show how many wins are needed to reach 25% on FreeCell:
+    arstats goal 25 freecell`},
+}
+
 func main() {
+	log.SetFlags(0)
+	log.SetPrefix(app.Name + ": ")
+	bi := readBuildInfo()
+	app.Version, app.Build = bi.version, bi.details()
+	configErr := loadConfig()
+	ctx, err := app.Parse(os.Args[1:])
 
-	var (
-		listFlag    bool
-		gameNameArg string
-	)
-
-	// Parse the command line. There are short and long names for each
-	// option
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr,
-			`Usage: arstats [OPTION]...
-
-Shows statistics for Aisleriot games played by the current user.
-
-Options:
-  -g, --game=GAMENAME	Name of game for which statistics are desired
-                        (Default is most recently played game)
-  -l, --list            List the names of all games played
-
-Output includes:
-  - Game name
-  - Number of wins
-  - Number of losses
-  - Total games played
-  - Best time
-  - Average time
-  - Worst time
-  - Winning percentage
-  - Number of wins to next higher percent
-  - Number of losses to next lower percent
-  `)
+	// A broken configuration file stops every command except the ones
+	// needed to find and fix it
+	if configErr != nil {
+		switch {
+		case errors.Is(err, cli.ErrHelp), errors.Is(err, cli.ErrVersion),
+			err == nil && (ctx.Command.Name == "help" || ctx.Command.Name == "config"):
+			log.Print(configErr)
+		default:
+			log.Fatal(configErr)
+		}
 	}
-	flag.BoolVar(&listFlag, "l", false, "List all games played")
-	flag.BoolVar(&listFlag, "list", false, "List all games played")
-	flag.StringVar(&gameNameArg, "g", "", "Game name")
-	flag.StringVar(&gameNameArg, "game", "", "Game name")
-	flag.Parse()
-
-	// Get the data provider
-	pdp, err := model.NewDataProvider()
-	if err != nil {
-		log.Fatal(err)
+	if err == nil {
+		err = setup(ctx)
 	}
-
-	// Handle the --list option
-	if listFlag {
-		view.List(pdp)
-		return
+	if err == nil {
+		err = ctx.Command.Run(ctx)
 	}
 
-	// Handle the --game option
-	gameName := ""
-	if gameNameArg == "" {
-		gameName = pdp.MostRecentGame()
-	} else {
-		gameName = gameNameArg
-	}
-	if gameName == "" {
-		view.ErrorMessage("No games have been played\n")
-		return
+	var usageErr *cli.UsageError
+	switch {
+	case err == nil, errors.Is(err, cli.ErrHelp), errors.Is(err, cli.ErrVersion):
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(os.Stderr, "%s: %v\n", app.Name, err)
+		if usageErr.Command != nil {
+			fmt.Fprintf(os.Stderr, "Try '%s %s --help' for more information.\n", app.Name, usageErr.Command.Name)
+		} else {
+			fmt.Fprintf(os.Stderr, "Try '%s --help' for more information.\n", app.Name)
+		}
+		os.Exit(2)
+	default:
+		log.Fatal(err)
 	}
-	gameName = model.ToDisplayName(gameName)
-
-	// Print the statistics
-	view.PrintStatistics(pdp, gameName)
 }
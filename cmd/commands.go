@@ -0,0 +1,1087 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/philhanna/aisleriot/cli"
+	"github.com/philhanna/aisleriot/model"
+	"github.com/philhanna/aisleriot/view"
+)
+
+// stdinFileName is the --file value that means standard input
+const stdinFileName = "-"
+
+// stdinData holds standard input once readStdin has read it
+var stdinData []byte
+
+// userHomeDir is the home directory of the user given with --user, or ""
+var userHomeDir string
+
+// setup applies the options accepted by every command
+func setup(ctx *cli.Context) error {
+
+	// Handle the --color and --color-threshold options
+	colorMode, err := view.ParseColorMode(ctx.String("color"))
+	if err != nil {
+		return err
+	}
+	view.SetColorMode(colorMode)
+	view.PercentThreshold, err = ctx.Int("color-threshold")
+	if err != nil {
+		return err
+	}
+
+	// Handle the --lang option
+	if lang := ctx.String("lang"); lang != "" {
+		view.CurrentLocale = view.LookupLocale(lang)
+	} else {
+		view.CurrentLocale = view.EnvLocale()
+	}
+
+	// Handle the --precision option
+	view.PercentPrecision, err = ctx.Int("precision")
+	if err != nil {
+		return err
+	}
+	if view.PercentPrecision < 0 || view.PercentPrecision > 6 {
+		return &cli.UsageError{Command: ctx.Command, Message: "--precision must be from 0 to 6"}
+	}
+
+	// Handle the --user option
+	if name := ctx.String("user"); name != "" {
+		if ctx.IsSet("file") {
+			return &cli.UsageError{Command: ctx.Command, Message: "--user and --file cannot be used together"}
+		}
+		u, err := model.DefaultResolver.Lookup(name)
+		if err != nil {
+			return err
+		}
+		if u.Err != nil {
+			return u.Err
+		}
+		ctx.Set("file", u.Filename)
+		if !ctx.IsSet("history") {
+			ctx.Set("history", model.HomeHistoryFileName(u.HomeDir))
+		}
+		userHomeDir = u.HomeDir
+	}
+
+	// Handle the --time-format option
+	view.DefaultTimeFormat, err = view.ParseTimeFormat(ctx.String("time-format"))
+	return err
+}
+
+// runShow prints the statistics for one game
+func runShow(ctx *cli.Context) error {
+	if ctx.Bool("list") {
+		return runList(ctx)
+	}
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	gameName, err := resolveGame(ctx, pdp)
+	if err != nil {
+		return err
+	}
+	if gameName == "" {
+		view.ErrorMessage(view.T("No games have been played") + "\n")
+		return nil
+	}
+
+	// Handle the --template and --template-string options
+	var tmpl *template.Template
+	switch {
+	case ctx.IsSet("template") && ctx.IsSet("template-string"):
+		return &cli.UsageError{Command: ctx.Command,
+			Message: "--template and --template-string are mutually exclusive"}
+	case ctx.IsSet("template"):
+		tmpl, err = view.NewTemplateFromFile(ctx.String("template"))
+	case ctx.IsSet("template-string"):
+		tmpl, err = view.NewTemplate("template-string", ctx.String("template-string"))
+	}
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		history, err := newHistory(ctx)
+		if err != nil {
+			return err
+		}
+		return view.PrintTemplate(os.Stdout, pdp, history, gameName, tmpl)
+	}
+
+	// Print the statistics in the --format
+	switch format := ctx.String("format"); format {
+	case "text":
+		view.PrintStatistics(pdp, gameName)
+		return nil
+	case "json":
+		return view.PrintJSON(os.Stdout, pdp, gameName)
+	default:
+		return &cli.UsageError{Command: ctx.Command,
+			Message: fmt.Sprintf("invalid output format %q", format)}
+	}
+}
+
+// runList prints the names of all games played
+func runList(ctx *cli.Context) error {
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.Bool("all") {
+		view.ListAll(pdp)
+		return nil
+	}
+	view.List(pdp)
+	return nil
+}
+
+// runTable prints the statistics for all games
+func runTable(ctx *cli.Context) error {
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	return view.PrintTable(os.Stdout, pdp, config.Expand(ctx.Args)...)
+}
+
+// runCheck prints the problems in the statistics of every game, and
+// returns an error if there are any
+func runCheck(ctx *cli.Context) error {
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	errs := pdp.Validate()
+	sNames := []string{}
+	for sName := range errs {
+		sNames = append(sNames, sName)
+	}
+	sort.Strings(sNames)
+	for _, sName := range sNames {
+		for _, problem := range strings.Split(errs[sName].Error(), "\n") {
+			fmt.Printf("%s: %s\n", sName, problem)
+		}
+	}
+	loc := view.CurrentLocale
+	if n := len(errs); n > 0 {
+		return fmt.Errorf(view.TN(n, "%s game has invalid statistics", "%s games have invalid statistics"),
+			loc.FormatInt(n))
+	}
+	if n := len(pdp.StatsSections()); !ctx.Bool("quiet") {
+		fmt.Printf(view.TN(n, "The statistics of %s game are valid", "The statistics of %s games are valid")+"\n",
+			loc.FormatInt(n))
+	}
+	return nil
+}
+
+// runRepair repairs a damaged statistics file, keeping a backup of it
+func runRepair(ctx *cli.Context) error {
+	var filename string
+	switch len(ctx.Args) {
+	case 0:
+		filename = statsFileName(ctx)
+	case 1:
+		filename = ctx.Args[0]
+	default:
+		return &cli.UsageError{Command: ctx.Command, Message: "expected at most one file"}
+	}
+	if filename == stdinFileName {
+		return &cli.UsageError{Command: ctx.Command, Message: "cannot repair standard input"}
+	}
+	if model.Packed(filename) {
+		msg := fmt.Sprintf("cannot repair %s, which is compressed or in an archive; extract it first", filename)
+		return &cli.UsageError{Command: ctx.Command, Message: msg}
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	repair := model.RepairData(data)
+	if repair.Changed() {
+		if err := writeRepair(ctx, filename, data, repair); err != nil {
+			return err
+		}
+	} else if len(repair.Problems) == 0 {
+		fmt.Println(view.T("Nothing to repair"))
+		return nil
+	}
+	if len(repair.Problems) == 0 {
+		return nil
+	}
+
+	// Impossible statistics are reported, but cannot be repaired
+	if repair.Changed() {
+		fmt.Println()
+	}
+	for _, problem := range repair.Problems {
+		fmt.Println(problem)
+	}
+	return errors.New(view.T("Some statistics are impossible and were not repaired; see arstats check"))
+}
+
+// writeRepair shows the changes made by a repair and, unless --dry-run
+// is given, replaces the file with the repaired one
+func writeRepair(ctx *cli.Context, filename string, data []byte, repair *model.Repair) error {
+	for _, change := range repair.Changes {
+		fmt.Println(change)
+	}
+	fmt.Println()
+	view.PrintLineDiff(os.Stdout, filename, filename+" (repaired)", data, repair.Data)
+	if ctx.Bool("dry-run") {
+		return nil
+	}
+
+	// Keep the original, then replace it
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	backup, err := writeBackup(filename, data, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, repair.Data, fi.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		return err
+	}
+	fmt.Printf("\n"+view.T("Repaired %s; the original is in %s")+"\n", filename, backup)
+	return nil
+}
+
+// writeBackup writes a copy of a file to FILE.bak, or to FILE.bak.N if
+// that exists, and returns its name
+func writeBackup(filename string, data []byte, perm os.FileMode) (string, error) {
+	for n := 0; ; n++ {
+		backup := filename + ".bak"
+		if n > 0 {
+			backup += "." + strconv.Itoa(n)
+		}
+		fp, err := os.OpenFile(backup, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := fp.Write(data); err != nil {
+			fp.Close()
+			return "", err
+		}
+		return backup, fp.Close()
+	}
+}
+
+// runGoal prints the number of wins needed to reach a percentage
+func runGoal(ctx *cli.Context) error {
+	if len(ctx.Args) == 0 {
+		return &cli.UsageError{Command: ctx.Command, Message: "missing percentage"}
+	}
+	pct, err := strconv.Atoi(ctx.Args[0])
+	if err != nil {
+		return &cli.UsageError{Command: ctx.Command,
+			Message: fmt.Sprintf("invalid percentage %q", ctx.Args[0])}
+	}
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	ctx.Args = ctx.Args[1:]
+	gameName, err := resolveGame(ctx, pdp)
+	if err != nil {
+		return err
+	}
+	if gameName == "" {
+		view.ErrorMessage(view.T("No games have been played") + "\n")
+		return nil
+	}
+	return view.PrintGoal(os.Stdout, pdp, gameName, pct)
+}
+
+// runExport writes the statistics for all games as JSON, or in the form
+// read by the leaderboard command
+func runExport(ctx *cli.Context) error {
+	if !ctx.Bool("share") {
+		pdp, err := newDataProvider(ctx)
+		if err != nil {
+			return err
+		}
+		return view.ExportJSON(os.Stdout, pdp, config.Expand(ctx.Args)...)
+	}
+	snapshot, err := loadSnapshot(statsFileName(ctx))
+	if err != nil {
+		return err
+	}
+	player := ctx.String("player")
+	if player == "" {
+		if player, err = userName(); err != nil {
+			return err
+		}
+	}
+	sNames := []string{}
+	for _, gameName := range config.Expand(ctx.Args) {
+		sNames = append(sNames, model.ToSectionName(model.ToDisplayName(gameName)))
+	}
+	share := model.NewShare(player, snapshot, sNames...)
+	if filename := ctx.String("key"); filename != "" {
+		key, err := readKey(filename)
+		if err != nil {
+			return err
+		}
+		share.Sign(key)
+	}
+	return share.Write(os.Stdout)
+}
+
+// runLeaderboard ranks the players whose shared statistics are in a
+// directory
+func runLeaderboard(ctx *cli.Context) error {
+	if len(ctx.Args) < 1 {
+		return &cli.UsageError{Command: ctx.Command, Message: "expected a directory"}
+	}
+	minGames, err := ctx.Int("min-games")
+	if err != nil {
+		return err
+	}
+	minTotal, err := ctx.Int("min-total")
+	if err != nil {
+		return err
+	}
+	var key []byte
+	if filename := ctx.String("key"); filename != "" {
+		if key, err = readKey(filename); err != nil {
+			return err
+		}
+	}
+	filenames, err := filepath.Glob(filepath.Join(ctx.Args[0], "*.json"))
+	if err != nil {
+		return err
+	}
+	shares := []*model.Share{}
+	for _, filename := range filenames {
+		share, err := readShare(filename)
+		if err == nil && key != nil {
+			err = share.Verify(key)
+		}
+		if err != nil {
+			log.Printf("%s: %v", filename, err)
+			continue
+		}
+		shares = append(shares, share)
+	}
+	lb := model.NewLeaderboard(shares, minGames, minTotal)
+	view.PrintLeaderboard(os.Stdout, lb, config.Expand(ctx.Args[1:])...)
+	return nil
+}
+
+// runUsers compares the statistics of the local users
+func runUsers(ctx *cli.Context) error {
+	users, err := model.DefaultResolver.Users()
+	if err != nil {
+		return err
+	}
+	view.PrintUsers(os.Stdout, users, config.Expand(ctx.Args)...)
+	return nil
+}
+
+// runTUI opens the full-screen browser
+func runTUI(ctx *cli.Context) error {
+	if statsFileName(ctx) == stdinFileName {
+		return &cli.UsageError{Command: ctx.Command, Message: "cannot browse standard input"}
+	}
+	tui, err := view.NewTUI(statsFileName(ctx))
+	if err != nil {
+		return err
+	}
+	return tui.Run()
+}
+
+// runSnapshot adds the current statistics to the history, if they have
+// changed since the last snapshot
+func runSnapshot(ctx *cli.Context) error {
+	history, err := newWritableHistory(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot, err := recordSnapshot(ctx, history)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		return history.Save()
+	}
+	return nil
+}
+
+// runImportHistory adds snapshots of backups of the statistics file to
+// the history
+func runImportHistory(ctx *cli.Context) error {
+	if len(ctx.Args) == 0 {
+		return &cli.UsageError{Command: ctx.Command, Message: "expected at least one file"}
+	}
+	timeFrom := ctx.String("time")
+	switch timeFrom {
+	case "auto", "name", "mtime":
+	default:
+		return &cli.UsageError{Command: ctx.Command, Message: fmt.Sprintf("invalid value for --time: %q", timeFrom)}
+	}
+	history, err := newWritableHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Read the files, rejecting the ones that cannot be read or dated
+	snapshots := []*model.Snapshot{}
+	rejected := 0
+	for _, filename := range ctx.Args {
+		snapshot, err := loadSnapshot(filename)
+		if err == nil && timeFrom != "mtime" {
+			t, ok := model.TimeFromName(filename)
+			switch {
+			case ok:
+				snapshot.Time = t.UTC()
+			case timeFrom == "name":
+				err = errors.New("no date in the file name")
+			}
+		}
+		if err != nil {
+			log.Printf("%s: %v", filename, err)
+			rejected++
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	// Add them in time order and find the resets
+	resets := map[*model.Snapshot]string{}
+	for _, snapshot := range history.Snapshots {
+		resets[snapshot] = strings.Join(snapshot.Resets, ";")
+	}
+	added := 0
+	for _, snapshot := range snapshots {
+		if history.Add(snapshot) {
+			added++
+		}
+	}
+	for _, snapshot := range history.MarkResets() {
+		if prev, ok := resets[snapshot]; ok && prev == strings.Join(snapshot.Resets, ";") {
+			continue
+		}
+		printResets(snapshot)
+	}
+	fmt.Printf(view.T("%s of %s snapshots added")+"\n",
+		view.CurrentLocale.FormatInt(added), view.CurrentLocale.FormatInt(len(snapshots)))
+
+	if !ctx.Bool("dry-run") && added > 0 {
+		if err := history.Save(); err != nil {
+			return err
+		}
+	}
+	if rejected > 0 {
+		loc := view.CurrentLocale
+		return fmt.Errorf(view.TN(rejected, "%s of %s files was rejected", "%s of %s files were rejected"),
+			loc.FormatInt(rejected), loc.FormatInt(len(ctx.Args)))
+	}
+	return nil
+}
+
+// runWatch adds a snapshot to the history whenever the statistics file
+// changes, until interrupted. New best times, higher percentages,
+// milestones, and achievements are printed and sent as notifications.
+func runWatch(ctx *cli.Context) error {
+	interval, err := ctx.Int("interval")
+	if err != nil {
+		return err
+	}
+	if interval < 1 {
+		return &cli.UsageError{Command: ctx.Command, Message: "--interval must be at least 1"}
+	}
+	if statsFileName(ctx) == stdinFileName {
+		return &cli.UsageError{Command: ctx.Command, Message: "cannot watch standard input"}
+	}
+	notifier, err := view.NewNotifier(ctx.String("notify"))
+	if err != nil {
+		return err
+	}
+	history, err := newWritableHistory(ctx)
+	if err != nil {
+		return err
+	}
+	rules, err := loadRules()
+	if err != nil {
+		return err
+	}
+	pa, err := newAchievements()
+	if err != nil {
+		return err
+	}
+	var modTime time.Time
+	for ; ; time.Sleep(time.Duration(interval) * time.Second) {
+		fi, err := os.Stat(statsFileName(ctx))
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		if fi.ModTime().Equal(modTime) {
+			continue
+		}
+		modTime = fi.ModTime()
+		before := history.Latest()
+		snapshot, err := recordSnapshot(ctx, history)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+		if err := history.Save(); err != nil {
+			return err
+		}
+		fmt.Printf(view.T("%s: recorded snapshot")+"\n", view.CurrentLocale.FormatDateTime(modTime))
+
+		// Tell the player what changed
+		milestones := []*model.Milestone{}
+		if before != nil {
+			milestones = model.Milestones(before, snapshot)
+		}
+		unlocked := pa.Update(rules, history, snapshot)
+		if len(unlocked) > 0 {
+			if err := saveAchievements(pa); err != nil {
+				return err
+			}
+		}
+		for _, n := range view.Notifications(milestones, unlocked) {
+			fmt.Printf("%s: %s\n", n.Summary, n.Body)
+			if notifier != nil {
+				if err := notifier.Notify(n); err != nil {
+					log.Print(err)
+				}
+			}
+		}
+	}
+}
+
+// runHistory prints the statistics for one game in each snapshot
+func runHistory(ctx *cli.Context) error {
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	gameName, err := resolveGame(ctx, pdp)
+	if err != nil {
+		return err
+	}
+	if gameName == "" {
+		view.ErrorMessage(view.T("No games have been played") + "\n")
+		return nil
+	}
+	history, err := newHistory(ctx)
+	if err != nil {
+		return err
+	}
+	history.MarkResets()
+	view.PrintHistory(os.Stdout, history, gameName)
+	return nil
+}
+
+// runStreaks prints the winning and losing streaks from the history
+func runStreaks(ctx *cli.Context) error {
+	history, err := newHistory(ctx)
+	if err != nil {
+		return err
+	}
+	view.PrintStreaks(os.Stdout, history, config.Expand(ctx.Args)...)
+	return nil
+}
+
+// runEras prints the statistics between resets from the history, with
+// the current statistics as the last snapshot
+func runEras(ctx *cli.Context) error {
+	history, err := newHistory(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot, err := loadSnapshot(statsFileName(ctx))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if snapshot != nil {
+		history.Add(snapshot)
+	}
+	history.MarkResets()
+	view.PrintEras(os.Stdout, history, config.Expand(ctx.Args)...)
+	return nil
+}
+
+// runReport prints the games played in each day, week, or month
+func runReport(ctx *cli.Context) error {
+	period, err := model.ParsePeriod(ctx.String("period"))
+	if err != nil {
+		return &cli.UsageError{Command: ctx.Command, Message: err.Error()}
+	}
+	var since, until time.Time
+	if ctx.String("since") != "" {
+		if since, err = parseDate(ctx, "since"); err != nil {
+			return err
+		}
+	}
+	if ctx.String("until") != "" {
+		if until, err = parseDate(ctx, "until"); err != nil {
+			return err
+		}
+		until = until.AddDate(0, 0, 1)
+	}
+	history, err := newHistory(ctx)
+	if err != nil {
+		return err
+	}
+	activities := history.Activity(period, since, until, time.Local)
+	if len(ctx.Args) > 0 {
+		selected := map[string]bool{}
+		for _, gameName := range config.Expand(ctx.Args) {
+			selected[model.ToSectionName(model.ToDisplayName(gameName))] = true
+		}
+		filtered := []*model.Activity{}
+		for _, pa := range activities {
+			if selected[pa.Section] {
+				filtered = append(filtered, pa)
+			}
+		}
+		activities = filtered
+	}
+	return view.PrintReport(os.Stdout, activities, period, ctx.String("format"))
+}
+
+// runCalendar prints a heat map of the games played on each day
+func runCalendar(ctx *cli.Context) error {
+	last := time.Now()
+	first := last.AddDate(-1, 0, 1)
+	if ctx.String("year") != "" {
+		year, err := ctx.Int("year")
+		if err != nil {
+			return err
+		}
+		first = time.Date(year, 1, 1, 0, 0, 0, 0, time.Local)
+		last = time.Date(year, 12, 31, 0, 0, 0, 0, time.Local)
+	}
+	history, err := newHistory(ctx)
+	if err != nil {
+		return err
+	}
+	sNames := []string{}
+	for _, gameName := range config.Expand(ctx.Args) {
+		sNames = append(sNames, model.ToSectionName(model.ToDisplayName(gameName)))
+	}
+	sessions := history.Sessions(model.DefaultSessionGap, sNames...)
+	calendar, err := view.NewCalendar(sessions, first, last, ctx.String("metric"))
+	if err != nil {
+		return &cli.UsageError{Command: ctx.Command, Message: err.Error()}
+	}
+	switch format := ctx.String("format"); format {
+	case "text":
+		calendar.WriteText(os.Stdout)
+	case "svg":
+		calendar.WriteSVG(os.Stdout)
+	default:
+		return &cli.UsageError{Command: ctx.Command,
+			Message: fmt.Sprintf("invalid output format %q", format)}
+	}
+	return nil
+}
+
+// runAchievements unlocks the achievements that have been met and
+// shows them all
+func runAchievements(ctx *cli.Context) error {
+	rules, err := loadRules()
+	if err != nil {
+		return err
+	}
+	pa, err := newAchievements()
+	if err != nil {
+		return err
+	}
+	history, err := newHistory(ctx)
+	if err != nil {
+		return err
+	}
+	pdp, err := newDataProvider(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := model.NewSnapshot(time.Now(), pdp)
+	if err != nil {
+		return err
+	}
+	before := len(pa.Unlocked)
+	pa.Update(rules, history, current)
+	if len(pa.Unlocked) != before {
+		if err := saveAchievements(pa); err != nil {
+			return err
+		}
+	}
+	view.PrintAchievements(os.Stdout, rules, pa)
+	return nil
+}
+
+// runDiff shows what changed in each game since a snapshot, a backup of
+// the statistics file, or an export
+func runDiff(ctx *cli.Context) error {
+	if ctx.IsSet("snapshot") && ctx.IsSet("against") {
+		return &cli.UsageError{Command: ctx.Command, Message: "--snapshot and --against cannot be used together"}
+	}
+	var before *model.Snapshot
+	if filename := ctx.String("against"); filename != "" {
+		var err error
+		if before, err = loadSnapshot(filename); err != nil {
+			return err
+		}
+	} else {
+		history, err := newHistory(ctx)
+		if err != nil {
+			return err
+		}
+		switch when := ctx.String("snapshot"); when {
+		case "", "latest":
+			before = history.Latest()
+		default:
+			day, err := parseDate(ctx, "snapshot")
+			if err != nil {
+				return err
+			}
+			before = history.Before(day)
+		}
+		if before == nil {
+			view.ErrorMessage(view.T("No snapshot was found") + "\n")
+			return nil
+		}
+	}
+	after, err := loadSnapshot(statsFileName(ctx))
+	if err != nil {
+		return err
+	}
+	view.PrintDiff(os.Stdout, before.Compare(after), config.Expand(ctx.Args)...)
+	return nil
+}
+
+// runCompletion writes a shell completion script
+func runCompletion(ctx *cli.Context) error {
+	if len(ctx.Args) != 1 {
+		return &cli.UsageError{Command: ctx.Command, Message: "expected one shell name"}
+	}
+	return ctx.App.WriteCompletion(os.Stdout, ctx.Args[0])
+}
+
+// runComplete lists the possible values of a dynamic completion, one
+// per line. It is run by the completion scripts.
+func runComplete(ctx *cli.Context) error {
+	if len(ctx.Args) != 1 {
+		return nil
+	}
+	var values []string
+	switch ctx.Args[0] {
+	case "games", "games-and-groups":
+		pdp, err := newDataProvider(ctx)
+		if err != nil {
+			return nil
+		}
+		values = completionGameNames(pdp)
+		if ctx.Args[0] == "games-and-groups" {
+			values = append(values, config.GroupNames()...)
+		}
+	case "shells":
+		values = cli.Shells
+	case "users":
+		users, err := model.DefaultResolver.Users()
+		if err != nil {
+			return nil
+		}
+		for _, u := range users {
+			values = append(values, u.Name)
+		}
+	}
+	for _, value := range values {
+		fmt.Println(value)
+	}
+	return nil
+}
+
+// runMan writes the man page
+func runMan(ctx *cli.Context) error {
+	date := readBuildInfo().buildTime
+	if date.IsZero() {
+		date = time.Now()
+	}
+	ctx.App.WriteMan(os.Stdout, date, manSections...)
+	return nil
+}
+
+// runConfig shows where the configuration file is
+func runConfig(ctx *cli.Context) error {
+	if len(ctx.Args) != 1 || ctx.Args[0] != "path" {
+		return &cli.UsageError{Command: ctx.Command, Message: "expected path"}
+	}
+	fmt.Println(config.Filename)
+	return nil
+}
+
+// runHelp prints the help for the program or for a command
+func runHelp(ctx *cli.Context) error {
+	if len(ctx.Args) == 0 {
+		ctx.App.PrintHelp(os.Stdout, nil)
+		return nil
+	}
+	cmd := ctx.App.Lookup(ctx.Args[0])
+	if cmd == nil {
+		return &cli.UsageError{Message: fmt.Sprintf("unknown command %q", ctx.Args[0])}
+	}
+	ctx.App.PrintHelp(os.Stdout, cmd)
+	return nil
+}
+
+// newDataProvider reads the statistics file given with --file, or the
+// default one
+func newDataProvider(ctx *cli.Context) (*model.DataProvider, error) {
+	filename := statsFileName(ctx)
+	if filename == stdinFileName {
+		data, err := readStdin()
+		if err != nil {
+			return nil, err
+		}
+		return model.NewDataProviderFromReader(bytes.NewReader(data))
+	}
+	return model.NewDataProvider(filename)
+}
+
+// statsFileName returns the statistics file given with --file, or the
+// default one
+func statsFileName(ctx *cli.Context) string {
+	if filename := ctx.String("file"); filename != "" {
+		return filename
+	}
+	return model.DefaultFileName()
+}
+
+// newHistory reads the history file given with --history, or the
+// default one
+func newHistory(ctx *cli.Context) (*model.History, error) {
+	if filename := ctx.String("history"); filename != "" {
+		return model.NewHistory(filename)
+	}
+	return model.NewHistory()
+}
+
+// newWritableHistory is like newHistory, but for commands that write
+// the history. The history of the user given with --user is not
+// written, since that user's files are only for reading.
+func newWritableHistory(ctx *cli.Context) (*model.History, error) {
+	history, err := newHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userHomeDir != "" && history.Filename == model.HomeHistoryFileName(userHomeDir) {
+		msg := fmt.Sprintf("cannot write the history of user %s; use --history to give another file", ctx.String("user"))
+		return nil, &cli.UsageError{Command: ctx.Command, Message: msg}
+	}
+	return history, nil
+}
+
+// recordSnapshot reads the statistics file and adds a snapshot of it to
+// the history, with the time the file was written. It returns the new
+// snapshot, or nil if the statistics had not changed.
+func recordSnapshot(ctx *cli.Context, history *model.History) (*model.Snapshot, error) {
+	snapshot, err := loadSnapshot(statsFileName(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !history.Add(snapshot) {
+		return nil, nil
+	}
+	printResets(snapshot)
+	return snapshot, nil
+}
+
+// printResets prints the games that were reset in a snapshot, if any
+func printResets(snapshot *model.Snapshot) {
+	if len(snapshot.Resets) == 0 {
+		return
+	}
+	names := []string{}
+	for _, sName := range snapshot.Resets {
+		names = append(names, model.ToDisplayName(sName))
+	}
+	fmt.Printf(view.T("%s: statistics reset for %s")+"\n",
+		view.CurrentLocale.FormatDateTime(snapshot.Time), strings.Join(names, ", "))
+}
+
+// loadSnapshot reads the statistics in a statistics file or in the
+// JSON written by the export command, with the time the file was
+// written
+func loadSnapshot(filename string) (*model.Snapshot, error) {
+	var data []byte
+	var modTime time.Time
+	if filename == stdinFileName {
+		var err error
+		if data, err = readStdin(); err != nil {
+			return nil, err
+		}
+		modTime = time.Now()
+	} else {
+		var err error
+		if data, modTime, err = model.ReadFile(filename); err != nil {
+			return nil, err
+		}
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		export, err := view.ReadExport(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		return export.Snapshot(modTime)
+	}
+	pdp, err := model.NewDataProviderFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return model.NewSnapshot(modTime, pdp)
+}
+
+// readStdin returns everything on standard input. It is read only once,
+// so that a command can use it more than once.
+func readStdin() ([]byte, error) {
+	if stdinData == nil {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		stdinData = append([]byte{}, data...)
+	}
+	return stdinData, nil
+}
+
+// readShare reads a file written by 'arstats export --share'
+func readShare(filename string) (*model.Share, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	return model.ReadShare(fp)
+}
+
+// readKey reads the key used to sign shared statistics
+func readKey(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("%s: empty key", filename)
+	}
+	return key, nil
+}
+
+// userName returns the login name of the current user
+func userName() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+// newAchievements reads the achievements unlocked by the user given with
+// --user, or by the current user
+func newAchievements() (*model.Achievements, error) {
+	if userHomeDir != "" {
+		return model.NewAchievements(model.HomeAchievementsFileName(userHomeDir))
+	}
+	return model.NewAchievements()
+}
+
+// saveAchievements writes the unlocked achievements, except for the
+// user given with --user, whose files are only for reading
+func saveAchievements(pa *model.Achievements) error {
+	if userHomeDir != "" {
+		return nil
+	}
+	return pa.Save()
+}
+
+// loadRules returns the built-in achievement rules followed by the
+// user's own
+func loadRules() ([]*model.Rule, error) {
+	rules, err := model.NewRules(model.DefaultRulesFileName())
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]*model.Rule{}, model.BuiltinRules...), rules...), nil
+}
+
+// parseDate returns the value of an option given as YYYY-MM-DD, at the
+// start of that day in the local time zone
+func parseDate(ctx *cli.Context, name string) (time.Time, error) {
+	value := ctx.String(name)
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return t, &cli.UsageError{Command: ctx.Command,
+			Message: fmt.Sprintf("invalid date for --%s: %q", name, value)}
+	}
+	return t, nil
+}
+
+// resolveGame returns the display name of the game given with --game
+// or as an argument, or of the most recently played game. Aliases from
+// the configuration file are replaced by the game they name.
+func resolveGame(ctx *cli.Context, pdp *model.DataProvider) (string, error) {
+	gameName := ctx.String("game")
+	switch {
+	case len(ctx.Args) > 1:
+		return "", &cli.UsageError{Command: ctx.Command, Message: "expected at most one game"}
+	case len(ctx.Args) == 1 && ctx.IsSet("game"):
+		return "", &cli.UsageError{Command: ctx.Command, Message: "game given twice"}
+	case len(ctx.Args) == 1:
+		gameName = ctx.Args[0]
+	case gameName == "":
+		gameName = pdp.MostRecentGame()
+	}
+	return model.ToDisplayName(config.Resolve(gameName)), nil
+}
+
+// completionGameNames returns the names of the games in the Recent list
+// followed by the other games with statistics, in the form typed on the
+// command line, e.g., "block-ten", and then the aliases
+func completionGameNames(pdp *model.DataProvider) []string {
+	names := []string{}
+	for _, item := range pdp.Inventory() {
+		names = append(names, model.ToRecentName(item.Section))
+	}
+	aliases := []string{}
+	for alias := range config.Aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return append(names, aliases...)
+}
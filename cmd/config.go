@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// config holds the preferences from the arstats configuration file
+var config = &model.Config{}
+
+// loadConfig reads the configuration file and makes its [defaults] and
+// [defaults.COMMAND] sections the defaults of the options, so that
+// options given on the command line override them. If the file cannot
+// be read, the configuration is empty.
+func loadConfig() error {
+	pc, err := model.NewConfig()
+	if err != nil {
+		config.Filename = model.DefaultConfigFileName()
+		return err
+	}
+	config = pc
+	for _, name := range sortedKeys(config.Defaults) {
+		if err := app.SetDefault(name, config.Defaults[name]); err != nil {
+			return fmt.Errorf("%s: [%s]: %v", config.Filename, model.DefaultsSection, err)
+		}
+	}
+	for _, command := range sortedKeys(config.CommandDefaults) {
+		defaults := config.CommandDefaults[command]
+		for _, name := range sortedKeys(defaults) {
+			if err := app.SetCommandDefault(command, name, defaults[name]); err != nil {
+				return fmt.Errorf("%s: [%s.%s]: %v", config.Filename, model.DefaultsSection, command, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
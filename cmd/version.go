@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// buildInfo holds the version information embedded by the go command
+type buildInfo struct {
+	version   string    // Module version, or "(devel)" for a local build
+	revision  string    // VCS revision, if known
+	modified  bool      // True if the working tree had local changes
+	buildTime time.Time // Time of the VCS revision, if known
+	goVersion string    // Version of Go used to build the program
+}
+
+// readBuildInfo returns the version information for this program
+func readBuildInfo() buildInfo {
+	bi := buildInfo{version: "(unknown)"}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return bi
+	}
+	bi.version = info.Main.Version
+	bi.goVersion = info.GoVersion
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			bi.revision = setting.Value
+		case "vcs.modified":
+			bi.modified = setting.Value == "true"
+		case "vcs.time":
+			bi.buildTime, _ = time.Parse(time.RFC3339, setting.Value)
+		}
+	}
+	return bi
+}
+
+// details returns the build details for --version, e.g.,
+// "(revision 71b80c3a1b2c, 2023-08-09, go1.20)"
+func (bi buildInfo) details() string {
+	details := ""
+	if bi.revision != "" {
+		revision := bi.revision
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		details = "revision " + revision
+		if bi.modified {
+			details += "-dirty"
+		}
+		if !bi.buildTime.IsZero() {
+			details += ", " + bi.buildTime.Format("2006-01-02")
+		}
+	}
+	if bi.goVersion != "" {
+		if details != "" {
+			details += ", "
+		}
+		details += bi.goVersion
+	}
+	if details == "" {
+		return ""
+	}
+	return fmt.Sprintf("(%s)", details)
+}
@@ -0,0 +1,315 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Rule is an achievement and the conditions for unlocking it. Every
+// condition that is not zero must be met. The per-game conditions
+// (Wins, Played, Percentage, BestUnder, and WinStreak) must all be met
+// by the same game, which is Game if it is given, or any game if not.
+type Rule struct {
+	Name        string
+	Description string
+	Game        string // Section name, or "" for any game
+
+	// Per-game conditions
+	Wins       int // At least this many wins
+	Played     int // At least this many games played
+	Percentage int // Winning percentage at least this
+	BestUnder  int // Best time less than this many seconds
+	WinStreak  int // At least this many wins in a row
+
+	// Conditions on all games together
+	DifferentGames int // At least this many different games played
+	TotalWins      int // At least this many wins in all games
+	TotalPlayed    int // At least this many games played in all games
+}
+
+// Achievements records when each achievement was unlocked. It is kept
+// in a file in .ini format, e.g.:
+//
+//	[unlocked]
+//	First win=2024-03-01T20:15:00Z
+type Achievements struct {
+	Filename string
+	Unlocked map[string]time.Time // Rule name to time unlocked
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+// UnlockedSection is the section of the achievements file
+const UnlockedSection = "unlocked"
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// BuiltinRules are the achievements that every user has
+var BuiltinRules = []*Rule{
+	{Name: "First win", Description: "Win a game", TotalWins: 1},
+	{Name: "Explorer", Description: "Play 20 different games", DifferentGames: 20},
+	{Name: "Centurion", Description: "Win 100 games of one game", Wins: 100},
+	{Name: "Marathon", Description: "Play 1,000 games", TotalPlayed: 1000},
+	{Name: "Steady hand", Description: "Reach 50% in a game with 200 or more games played",
+		Percentage: 50, Played: 200},
+	{Name: "Speed demon", Description: "Win a game in under 2 minutes", BestUnder: 120},
+	{Name: "Hot streak", Description: "Win 5 games of one game in a row", WinStreak: 5},
+}
+
+// ---------------------------------------------------------------------
+// Constructors
+// ---------------------------------------------------------------------
+
+// NewRules reads achievement rules from a file in .ini format, with
+// one section per achievement, e.g.:
+//
+//	[FreeCell master]
+//	description = Win 100 FreeCell games
+//	game = freecell
+//	wins = 100
+//
+// A missing file is not an error; it gives no rules.
+func NewRules(filename string) ([]*Rule, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []*Rule{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sm, err := ParseData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	rules := []*Rule{}
+	for name, section := range sm {
+		rule, err := newRule(name, section)
+		if err != nil {
+			return nil, fmt.Errorf("%s: [%s]: %v", filename, name, err)
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// NewAchievements reads the specified achievements file, or the default
+// one if none is specified. A missing file is not an error; it means
+// nothing has been unlocked.
+func NewAchievements(filenames ...string) (*Achievements, error) {
+	var filename string
+	switch len(filenames) {
+	case 0:
+		filename = DefaultAchievementsFileName()
+	default:
+		filename = filenames[0]
+	}
+	pa := &Achievements{Filename: filename, Unlocked: map[string]time.Time{}}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pa, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sm, err := ParseData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	for name, value := range sm[UnlockedSection] {
+		t, err := time.Parse(SnapshotTimeFormat, strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid time for %q: %q", filename, name, value)
+		}
+		pa.Unlocked[strings.TrimSpace(name)] = t
+	}
+	return pa, nil
+}
+
+// newRule creates a rule from the keys in its section
+func newRule(name string, section map[string]string) (*Rule, error) {
+	rule := &Rule{Name: name}
+	for key, value := range section {
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		var p *int
+		switch key {
+		case "description":
+			rule.Description = value
+			continue
+		case "game":
+			rule.Game = ToSectionName(ToDisplayName(value))
+			continue
+		case "best-under":
+			seconds, err := ParseSeconds(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid best-under %q", value)
+			}
+			rule.BestUnder = seconds
+			continue
+		case "wins":
+			p = &rule.Wins
+		case "played":
+			p = &rule.Played
+		case "percentage":
+			p = &rule.Percentage
+		case "win-streak":
+			p = &rule.WinStreak
+		case "different-games":
+			p = &rule.DifferentGames
+		case "total-wins":
+			p = &rule.TotalWins
+		case "total-played":
+			p = &rule.TotalPlayed
+		default:
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s %q", key, value)
+		}
+		*p = n
+	}
+	return rule, nil
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Met returns true if the statistics in the snapshot meet all the
+// conditions of the rule. Streaks are found from the history, which may
+// be nil.
+func (r *Rule) Met(snapshot *Snapshot, history *History) bool {
+	totalWins, totalPlayed, differentGames := 0, 0, 0
+	for _, ps := range snapshot.Stats {
+		totalWins += ps.Wins()
+		totalPlayed += ps.Total()
+		if ps.Total() > 0 {
+			differentGames++
+		}
+	}
+	if totalWins < r.TotalWins || totalPlayed < r.TotalPlayed || differentGames < r.DifferentGames {
+		return false
+	}
+	if !r.perGame() {
+		return true
+	}
+	for sName, ps := range snapshot.Stats {
+		if r.Game == "" || r.Game == sName {
+			if r.metBy(sName, ps, history) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// perGame returns true if the rule has conditions on a single game
+func (r *Rule) perGame() bool {
+	return r.Game != "" || r.Wins > 0 || r.Played > 0 || r.Percentage > 0 ||
+		r.BestUnder > 0 || r.WinStreak > 0
+}
+
+// metBy returns true if one game meets the per-game conditions
+func (r *Rule) metBy(sName string, ps *Statistics, history *History) bool {
+	switch {
+	case ps.Wins() < r.Wins, ps.Total() < r.Played:
+		return false
+	case r.Percentage > 0 && (ps.Total() == 0 || ps.Percentage() < r.Percentage):
+		return false
+	case r.BestUnder > 0 && (!ps.HasTimes() || ps.Best() >= r.BestUnder):
+		return false
+	case r.WinStreak > 0:
+		if history == nil {
+			return false
+		}
+		return NewStreaks(history.Series(sName)).LongestWins.Length >= r.WinStreak
+	}
+	return true
+}
+
+// Update unlocks the rules that are met and were not unlocked before,
+// and returns them. Each is given the time of the first snapshot in the
+// history that meets it, or the time of current if none does. Rules
+// with a streak are only checked against current. The history may be
+// nil.
+func (pa *Achievements) Update(rules []*Rule, history *History, current *Snapshot) []*Rule {
+	unlocked := []*Rule{}
+	for _, rule := range rules {
+		if _, ok := pa.Unlocked[rule.Name]; ok {
+			continue
+		}
+		if rule.WinStreak == 0 && history != nil {
+			for _, snapshot := range history.Snapshots {
+				if rule.Met(snapshot, nil) {
+					pa.Unlocked[rule.Name] = snapshot.Time
+					break
+				}
+			}
+		}
+		if _, ok := pa.Unlocked[rule.Name]; !ok && rule.Met(current, history) {
+			pa.Unlocked[rule.Name] = current.Time
+		}
+		if _, ok := pa.Unlocked[rule.Name]; ok {
+			unlocked = append(unlocked, rule)
+		}
+	}
+	return unlocked
+}
+
+// Save writes the achievements file, creating its directory if
+// necessary
+func (pa *Achievements) Save() error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[%s]\n", UnlockedSection)
+	names := []string{}
+	for name := range pa.Unlocked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s=%s\n", name, pa.Unlocked[name].UTC().Format(SnapshotTimeFormat))
+	}
+	if err := os.MkdirAll(filepath.Dir(pa.Filename), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(pa.Filename, buf.Bytes(), 0o644)
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// DefaultAchievementsFileName returns the name of the file that records
+// unlocked achievements, next to the history file
+func DefaultAchievementsFileName() string {
+	return filepath.Join(filepath.Dir(DefaultHistoryFileName()), "achievements")
+}
+
+// HomeAchievementsFileName returns the name of the file of unlocked
+// achievements for another user whose home directory is homeDir
+func HomeAchievementsFileName(homeDir string) string {
+	return filepath.Join(filepath.Dir(HomeHistoryFileName(homeDir)), "achievements")
+}
+
+// DefaultRulesFileName returns the name of the user's achievement rules
+// file, next to the configuration file
+func DefaultRulesFileName() string {
+	return filepath.Join(filepath.Dir(DefaultConfigFileName()), "achievements")
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRules(t *testing.T) {
+	rules, err := NewRules(filepath.Join(testdata, "achievements.ini"))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Rule{
+		{Name: "FreeCell master", Description: "Win 100 FreeCell games", Game: "freecell.scm", Wins: 100},
+		{Name: "Quick", BestUnder: 90},
+	}, rules)
+
+	rules, err = NewRules(filepath.Join(testdata, "bogus.conf"))
+	assert.Nil(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestNewRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"unknown key", "[x]\nlosses=3\n", `[x]: unknown key "losses"`},
+		{"not a number", "[x]\nwins=many\n", `[x]: invalid wins "many"`},
+		{"negative", "[x]\nplayed=-1\n", `[x]: invalid played "-1"`},
+		{"bad time", "[x]\nbest-under=1:xx\n", `[x]: invalid best-under "1:xx"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "achievements")
+			assert.Nil(t, os.WriteFile(filename, []byte(tt.data), 0o644))
+			_, err := NewRules(filename)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestRule_Met(t *testing.T) {
+	pdp, err := NewDataProvider(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	snapshot, err := NewSnapshot(time.Now(), pdp)
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		rule     *Rule
+		expected bool
+	}{
+		{"total wins", &Rule{TotalWins: 220}, true},
+		{"too many total wins", &Rule{TotalWins: 221}, false},
+		{"different games", &Rule{DifferentGames: 4}, true},
+		{"any game wins", &Rule{Wins: 100}, true},
+		{"named game wins", &Rule{Game: "spider.scm", Wins: 100}, false},
+		{"same game", &Rule{Wins: 50, Percentage: 80}, true},
+		{"not the same game", &Rule{Wins: 50, Played: 240, Percentage: 80}, false},
+		{"best under", &Rule{BestUnder: 89}, true},
+		{"best not under", &Rule{BestUnder: 88}, false},
+		{"streak without history", &Rule{WinStreak: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Met(snapshot, nil))
+		})
+	}
+}
+
+func TestAchievements_Update(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	history := &History{}
+	history.Add(newTestSnapshot(t1, "freecell.scm", "0;1;0;0;"))
+	history.Add(newTestSnapshot(t2, "freecell.scm", "1;2;60;60;"))
+	current := newTestSnapshot(t2.Add(time.Hour), "freecell.scm", "2;3;60;70;")
+	rules := []*Rule{
+		{Name: "First win", TotalWins: 1},
+		{Name: "Two wins", Wins: 2},
+		{Name: "Three wins", Wins: 3},
+	}
+
+	filename := filepath.Join(t.TempDir(), "achievements")
+	pa, err := NewAchievements(filename)
+	assert.Nil(t, err)
+	unlocked := pa.Update(rules, history, current)
+	assert.Equal(t, []*Rule{rules[0], rules[1]}, unlocked)
+	assert.Equal(t, t2, pa.Unlocked["First win"])
+	assert.Equal(t, current.Time, pa.Unlocked["Two wins"])
+	assert.Empty(t, pa.Update(rules, history, current))
+	assert.Nil(t, pa.Save())
+
+	pa, err = NewAchievements(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pa.Unlocked))
+	assert.True(t, pa.Unlocked["First win"].Equal(t2))
+}
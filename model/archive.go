@@ -0,0 +1,175 @@
+package model
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+// MemberSeparator separates the name of an archive from the name of a
+// file in it, e.g., "backup.tar.gz#home/me/.config/gnome-games/aisleriot"
+const MemberSeparator = "#"
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// ReadFile returns the contents of a file and the time it was written.
+// Files ending in .gz or .bz2 are decompressed. A name of the form
+// ARCHIVE#MEMBER reads MEMBER from a .tar, .tar.gz, .tgz, .tar.bz2,
+// .tbz2, or .zip archive, unless a file with the whole name exists.
+func ReadFile(name string) ([]byte, time.Time, error) {
+	i := strings.LastIndex(name, MemberSeparator)
+	if i < 0 {
+		return readCompressed(name)
+	}
+	if _, err := os.Stat(name); err == nil {
+		return readCompressed(name)
+	}
+	return readMember(name[:i], name[i+1:])
+}
+
+// Packed returns true if ReadFile would decompress the file or read it
+// from an archive, so that its contents cannot be written back to it
+func Packed(name string) bool {
+	if strings.Contains(name, MemberSeparator) {
+		if _, err := os.Stat(name); err != nil {
+			return true
+		}
+	}
+	switch lower := strings.ToLower(name); {
+	case strings.HasSuffix(lower, ".gz"), strings.HasSuffix(lower, ".tgz"),
+		strings.HasSuffix(lower, ".bz2"), strings.HasSuffix(lower, ".tbz2"):
+		return true
+	}
+	return false
+}
+
+// readCompressed reads a file, decompressing it if its name ends in .gz
+// or .bz2
+func readCompressed(name string) ([]byte, time.Time, error) {
+	fp, err := os.Open(name)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer fp.Close()
+	fi, err := fp.Stat()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	r, err := decompress(name, fp)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%s: %v", name, err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%s: %v", name, err)
+	}
+	return data, fi.ModTime(), nil
+}
+
+// readMember reads one file from an archive
+func readMember(archive, member string) ([]byte, time.Time, error) {
+	member = cleanMember(member)
+	switch lower := strings.ToLower(archive); {
+	case strings.HasSuffix(lower, ".zip"):
+		return readZipMember(archive, member)
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"),
+		strings.HasSuffix(lower, ".tgz"), strings.HasSuffix(lower, ".tar.bz2"),
+		strings.HasSuffix(lower, ".tbz2"):
+		return readTarMember(archive, member)
+	}
+	return nil, time.Time{}, fmt.Errorf("%s: not a .tar, .tar.gz, .tar.bz2, or .zip archive", archive)
+}
+
+// noMember returns the error for a member that is not in an archive
+func noMember(archive, member string) error {
+	return fmt.Errorf("%s: no member %q: %w", archive, member, fs.ErrNotExist)
+}
+
+// readZipMember reads one file from a zip archive
+func readZipMember(archive, member string) ([]byte, time.Time, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if cleanMember(f.Name) != member || f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %v", archive, err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %v", archive, err)
+		}
+		return data, f.Modified, nil
+	}
+	return nil, time.Time{}, noMember(archive, member)
+}
+
+// readTarMember reads one file from a tar archive, which may be
+// compressed
+func readTarMember(archive, member string) ([]byte, time.Time, error) {
+	fp, err := os.Open(archive)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer fp.Close()
+	r, err := decompress(archive, fp)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%s: %v", archive, err)
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, time.Time{}, noMember(archive, member)
+		}
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %v", archive, err)
+		}
+		if cleanMember(hdr.Name) != member || hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %v", archive, err)
+		}
+		return data, hdr.ModTime, nil
+	}
+}
+
+// decompress returns a reader that decompresses a file whose name ends
+// in .gz, .tgz, .bz2, or .tbz2, or the reader itself for other names
+func decompress(name string, r io.Reader) (io.Reader, error) {
+	switch lower := strings.ToLower(name); {
+	case strings.HasSuffix(lower, ".gz"), strings.HasSuffix(lower, ".tgz"):
+		return gzip.NewReader(r)
+	case strings.HasSuffix(lower, ".bz2"), strings.HasSuffix(lower, ".tbz2"):
+		return bzip2.NewReader(r), nil
+	}
+	return r, nil
+}
+
+// cleanMember returns the name of an archive member without a leading
+// "/" or "./"
+func cleanMember(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
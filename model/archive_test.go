@@ -0,0 +1,95 @@
+package model
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFile(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	const member = "home/me/.config/gnome-games/aisleriot"
+	memberTime := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filename string
+		modTime  time.Time // Zero if it is the time of the file
+	}{
+		{"plain", "aisleriot", time.Time{}},
+		{"gzip", "aisleriot.gz", time.Time{}},
+		{"bzip2", "aisleriot.bz2", time.Time{}},
+		{"tar", "backup.tar#" + member, memberTime},
+		{"tar.gz", "backup.tar.gz#" + member, memberTime},
+		{"tar.bz2", "backup.tar.bz2#" + member, memberTime},
+		{"zip", "backup.zip#" + member, memberTime},
+		{"leading slash", "backup.tar.gz#/" + member, memberTime},
+		{"leading dot", "backup.zip#./" + member, memberTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, modTime, err := ReadFile(filepath.Join(testdata, tt.filename))
+			assert.Nil(t, err)
+			assert.Equal(t, string(want), string(data))
+			if !tt.modTime.IsZero() {
+				assert.True(t, tt.modTime.Equal(modTime), "%v", modTime)
+			}
+		})
+	}
+}
+
+func TestReadFile_Errors(t *testing.T) {
+	notGzip := filepath.Join(t.TempDir(), "aisleriot.gz")
+	assert.Nil(t, os.WriteFile(notGzip, []byte("[Aisleriot Config]\n"), 0o644))
+	tests := []struct {
+		name     string
+		filename string
+		notExist bool
+	}{
+		{"missing file", filepath.Join(testdata, "bogus.gz"), true},
+		{"missing archive", filepath.Join(testdata, "bogus.tar#aisleriot"), true},
+		{"missing member", filepath.Join(testdata, "backup.tar.gz#home/me/aisleriot"), true},
+		{"directory member", filepath.Join(testdata, "backup.zip#home/me"), true},
+		{"not an archive", filepath.Join(testdata, "aisleriot#aisleriot"), false},
+		{"not compressed", notGzip, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ReadFile(tt.filename)
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.notExist, errors.Is(err, fs.ErrNotExist))
+		})
+	}
+}
+
+func TestNewDataProvider_Compressed(t *testing.T) {
+	pdp, err := NewDataProvider(filepath.Join(testdata, "backup.tar.bz2#home/me/.config/gnome-games/aisleriot"))
+	assert.Nil(t, err)
+	assert.Equal(t, "spider", pdp.MostRecentGame())
+}
+
+func TestPacked(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected bool
+	}{
+		{"plain", "aisleriot", false},
+		{"gzip", "aisleriot.gz", true},
+		{"bzip2", "aisleriot.BZ2", true},
+		{"member", "backup.tar#" + "home/me/.config/gnome-games/aisleriot", true},
+		{"plain with separator", "aisleriot#1", false},
+	}
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "aisleriot#1"), []byte{}, 0o644))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Packed(filepath.Join(dir, tt.filename)))
+		})
+	}
+}
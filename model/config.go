@@ -0,0 +1,179 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Config holds the arstats preferences read from its own configuration
+// file, which is in .ini format, e.g.:
+//
+//	[defaults]
+//	game = spider
+//	time-format = compact
+//
+//	[defaults.report]
+//	format = markdown
+//
+//	[aliases]
+//	fc = freecell
+//
+//	[groups]
+//	patience = klondike, spider, freecell
+//
+// The defaults in a [defaults.COMMAND] section apply only to that
+// command.
+type Config struct {
+	Filename        string                       // Where the configuration was read from
+	Defaults        map[string]string            // Option name to default value
+	CommandDefaults map[string]map[string]string // Command name to option name to default value
+	Aliases         map[string]string            // Alias to game name
+	Groups          map[string][]string          // Group name to game names
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	DefaultsSection = "defaults"
+	AliasesSection  = "aliases"
+	GroupsSection   = "groups"
+)
+
+// ---------------------------------------------------------------------
+// Constructor
+// ---------------------------------------------------------------------
+
+// NewConfig reads the specified configuration file, or the default one
+// if none is specified. A missing file is not an error; it gives an
+// empty configuration.
+func NewConfig(filenames ...string) (*Config, error) {
+	var filename string
+	switch len(filenames) {
+	case 0:
+		filename = DefaultConfigFileName()
+	default:
+		filename = filenames[0]
+	}
+	config := &Config{
+		Filename:        filename,
+		Defaults:        map[string]string{},
+		CommandDefaults: map[string]map[string]string{},
+		Aliases:         map[string]string{},
+		Groups:          map[string][]string{},
+	}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sm, err := ParseData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	for sectionName, section := range sm {
+		if command, ok := strings.CutPrefix(sectionName, DefaultsSection+"."); ok && command != "" {
+			defaults := map[string]string{}
+			for key, value := range section {
+				defaults[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+			config.CommandDefaults[command] = defaults
+			continue
+		}
+		for key, value := range section {
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			switch sectionName {
+			case DefaultsSection:
+				config.Defaults[key] = value
+			case AliasesSection:
+				config.Aliases[strings.ToLower(key)] = value
+			case GroupsSection:
+				config.Groups[strings.ToLower(key)] = splitList(value)
+			default:
+				return nil, fmt.Errorf("%s: unknown section [%s]", filename, sectionName)
+			}
+		}
+	}
+	return config, nil
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Resolve returns the game name for an alias, or the name unchanged if
+// it is not an alias.
+func (pc *Config) Resolve(gameName string) string {
+	if name, ok := pc.Aliases[strings.ToLower(strings.TrimSpace(gameName))]; ok {
+		return name
+	}
+	return gameName
+}
+
+// Expand replaces group names with the games in the group and resolves
+// aliases. Games are listed once, in the order first given.
+func (pc *Config) Expand(names []string) []string {
+	games := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		members, ok := pc.Groups[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			members = []string{name}
+		}
+		for _, member := range members {
+			member = pc.Resolve(member)
+			if sName := ToSectionName(ToDisplayName(member)); !seen[sName] {
+				seen[sName] = true
+				games = append(games, member)
+			}
+		}
+	}
+	return games
+}
+
+// GroupNames returns the sorted names of all groups
+func (pc *Config) GroupNames() []string {
+	names := []string{}
+	for name := range pc.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// DefaultConfigFileName returns the name of the arstats configuration
+// file in the user .config directory.
+func DefaultConfigFileName() string {
+	configDir, _ := os.UserConfigDir()
+	filename := filepath.Join(configDir, "arstats", "config")
+	return filename
+}
+
+// splitList splits a list of names separated by commas or semicolons
+func splitList(value string) []string {
+	list := []string{}
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';'
+	}) {
+		if name = strings.TrimSpace(name); name != "" {
+			list = append(list, name)
+		}
+	}
+	return list
+}
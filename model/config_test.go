@@ -0,0 +1,71 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	filename := filepath.Join(testdata, "arstats.ini")
+	pc, err := NewConfig(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, filename, pc.Filename)
+	assert.Equal(t, map[string]string{"game": "spider", "time-format": "compact"}, pc.Defaults)
+	assert.Equal(t, map[string]map[string]string{"report": {"format": "markdown"}}, pc.CommandDefaults)
+	assert.Equal(t, map[string]string{"fc": "freecell"}, pc.Aliases)
+	assert.Equal(t, []string{"klondike", "fc", "spider"}, pc.Groups["patience"])
+	assert.Equal(t, []string{"patience"}, pc.GroupNames())
+}
+
+func TestNewConfigMissing(t *testing.T) {
+	pc, err := NewConfig(filepath.Join(testdata, "bogus.conf"))
+	assert.Nil(t, err)
+	assert.Empty(t, pc.Defaults)
+	assert.Equal(t, "freecell", pc.Expand([]string{"freecell"})[0])
+}
+
+func TestNewConfigUnknownSection(t *testing.T) {
+	_, err := NewConfig(filepath.Join(testdata, "arstats_bogus.ini"))
+	assert.ErrorContains(t, err, "unknown section [colors]")
+}
+
+func TestConfig_Resolve(t *testing.T) {
+	pc, err := NewConfig(filepath.Join(testdata, "arstats.ini"))
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		gameName string
+		expected string
+	}{
+		{"alias", "fc", "freecell"},
+		{"alias any case", "Fc", "freecell"},
+		{"not an alias", "spider", "spider"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pc.Resolve(tt.gameName))
+		})
+	}
+}
+
+func TestConfig_Expand(t *testing.T) {
+	pc, err := NewConfig(filepath.Join(testdata, "arstats.ini"))
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{"none", []string{}, []string{}},
+		{"group", []string{"patience"}, []string{"klondike", "freecell", "spider"}},
+		{"duplicates", []string{"Spider", "patience"}, []string{"Spider", "klondike", "freecell"}},
+		{"alias", []string{"fc", "canfield"}, []string{"freecell", "canfield"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pc.Expand(tt.names))
+		})
+	}
+}
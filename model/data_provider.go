@@ -4,9 +4,12 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -28,6 +31,7 @@ const (
 	HeaderSection = "Aisleriot Config"
 	RecentItem    = "Recent"
 	StatsKey      = "Statistic"
+	OptionsKey    = "Options"
 )
 
 // ---------------------------------------------------------------------
@@ -47,12 +51,10 @@ var (
 
 // NewDataProvider reads the specified configuration file, which is in
 // .ini format, and returns a pointer to a DataProvider having the
-// file's contents parsed into named sections and their lines.
+// file's contents parsed into named sections and their lines. The file
+// may be compressed or in an archive; see ReadFile.
 func NewDataProvider(filenames ...string) (*DataProvider, error) {
 
-	// Create a new, empty data provider structure
-	pdp := new(DataProvider)
-
 	// Read the specified .ini file
 	var filename string
 	switch len(filenames) {
@@ -61,19 +63,42 @@ func NewDataProvider(filenames ...string) (*DataProvider, error) {
 	default:
 		filename = filenames[0]
 	}
-	data, err := os.ReadFile(filename)
+	data, _, err := ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse its contents
-	pdp.Sections, err = ParseData(data)
+	return newDataProvider(data)
+}
+
+// NewDataProviderFromReader reads a configuration file in .ini format
+// from r, e.g., from standard input
+func NewDataProviderFromReader(r io.Reader) (*DataProvider, error) {
+	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
+	return newDataProvider(data)
+}
 
-	// Done
-	return pdp, nil
+// NewDataProviderFromFS reads the configuration file with the specified
+// name from a file system, e.g., from an fstest.MapFS in tests
+func NewDataProviderFromFS(fsys fs.FS, name string) (*DataProvider, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return newDataProvider(data)
+}
+
+// newDataProvider parses the contents of a configuration file
+func newDataProvider(data []byte) (*DataProvider, error) {
+	sections, err := ParseData(data)
+	if err != nil {
+		return nil, err
+	}
+	return &DataProvider{Sections: sections}, nil
 }
 
 // ---------------------------------------------------------------------
@@ -104,6 +129,33 @@ func (pdp *DataProvider) MostRecentGame() string {
 	return list[0]
 }
 
+// StatsSections returns the sorted names of all sections that have
+// statistics, whether or not the game is in the "Recent" list.
+func (pdp *DataProvider) StatsSections() []string {
+	names := []string{}
+	for sName, section := range pdp.Sections {
+		if _, ok := section[StatsKey]; ok {
+			names = append(names, sName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate returns the errors in the statistics of each section that
+// has them, by section name. The error for statistics that cannot be
+// parsed is the parse error; otherwise it is the one from
+// Statistics.Validate.
+func (pdp *DataProvider) Validate() map[string]error {
+	errs := map[string]error{}
+	for _, sName := range pdp.StatsSections() {
+		if _, err := NewStatisticsFromString(pdp.Sections[sName][StatsKey]); err != nil {
+			errs[sName] = err
+		}
+	}
+	return errs
+}
+
 // ---------------------------------------------------------------------
 // Functions
 // ---------------------------------------------------------------------
@@ -112,8 +164,19 @@ func (pdp *DataProvider) MostRecentGame() string {
 // directory.
 func DefaultFileName() string {
 	configDir, _ := os.UserConfigDir()
-	filename := filepath.Join(configDir, "gnome-games", "aisleriot")
-	return filename
+	return statsFileIn(configDir)
+}
+
+// HomeFileName returns the name of the .ini file for another user whose
+// home directory is homeDir. It is in the default .config directory
+// there, since that user's environment is not known.
+func HomeFileName(homeDir string) string {
+	return statsFileIn(filepath.Join(homeDir, ".config"))
+}
+
+// statsFileIn returns the name of the .ini file in a .config directory
+func statsFileIn(configDir string) string {
+	return filepath.Join(configDir, "gnome-games", "aisleriot")
 }
 
 // ParseData reads the contents of an .ini file and returns a map of its
@@ -209,6 +272,13 @@ func ToSectionName(gameName string) string {
 	return sName
 }
 
+// ToRecentName converts a section name to the name of the game in the
+// Recent list. ".scm" is removed and underscores are converted to
+// hyphens.
+func ToRecentName(sName string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(sName, ".scm"), "_", "-")
+}
+
 // titleCase makes the first character of a name uppercase, and the
 // remainder (if any) lower case
 func titleCase(name string) string {
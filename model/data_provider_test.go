@@ -2,10 +2,13 @@ package model
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -39,6 +42,44 @@ func TestDefaultFileName(t *testing.T) {
 	assert.Contains(t, filename, username)
 }
 
+func TestNewDataProviderFromReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		recent  string
+		wantErr bool
+	}{
+		{"valid", "[Aisleriot Config]\nRecent=spider;freecell;\n\n[spider.scm]\nStatistic=1;2;60;60;\n", "spider", false},
+		{"empty", "", "", false},
+		{"no section", "Recent=spider;\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdp, err := NewDataProviderFromReader(strings.NewReader(tt.data))
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.recent, pdp.MostRecentGame())
+		})
+	}
+}
+
+func TestNewDataProviderFromFS(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	fsys := fstest.MapFS{
+		"home/alice/.config/gnome-games/aisleriot": &fstest.MapFile{Data: data},
+	}
+	pdp, err := NewDataProviderFromFS(fsys, "home/alice/.config/gnome-games/aisleriot")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"canfield.scm", "freecell.scm", "klondike.scm", "spider.scm"}, pdp.StatsSections())
+
+	_, err = NewDataProviderFromFS(fsys, "home/bob/.config/gnome-games/aisleriot")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
 func TestParseData(t *testing.T) {
 	stoogeFile := filepath.Join(testdata, "stooges.ini")
 	stooges, err := os.ReadFile(stoogeFile)
@@ -199,6 +240,24 @@ func TestToDisplayName(t *testing.T) {
 	}
 }
 
+func TestToRecentName(t *testing.T) {
+	tests := []struct {
+		name     string
+		sName    string
+		expected string
+	}{
+		{"simple", "freecell.scm", "freecell"},
+		{"with underscore", "auld_lang_syne.scm", "auld-lang-syne"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ToRecentName(tt.sName)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func Test_titleCase(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -219,3 +278,32 @@ func Test_titleCase(t *testing.T) {
 		})
 	}
 }
+
+func TestDataProvider_StatsSections(t *testing.T) {
+	pdp, err := NewDataProvider(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	expected := []string{"canfield.scm", "freecell.scm", "klondike.scm", "spider.scm"}
+	assert.Equal(t, expected, pdp.StatsSections())
+}
+
+func TestDataProvider_Validate(t *testing.T) {
+	pdp, err := NewDataProvider(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	assert.Empty(t, pdp.Validate())
+
+	pdp, err = NewDataProviderFromReader(strings.NewReader(`
+[freecell.scm]
+Statistic=5;4;60;90;
+
+[klondike.scm]
+Statistic=5;bogus;
+
+[spider.scm]
+Statistic=1;2;60;60;
+`))
+	assert.Nil(t, err)
+	errs := pdp.Validate()
+	assert.Equal(t, 2, len(errs))
+	assert.ErrorIs(t, errs["freecell.scm"], ErrImpossible)
+	assert.Contains(t, errs["klondike.scm"].Error(), "expected 4 values")
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Game is one game's section of the statistics file
+type Game struct {
+	Section  string            // Section name, e.g., "block_ten.scm"
+	Name     string            // Display name, e.g., "Block Ten"
+	HasStats bool              // False for a game in Recent with no statistics
+	Stats    *Statistics       // Zero if HasStats is false
+	Options  string            // Options value as in the file, e.g., "5", if any
+	Other    map[string]string // Items other than Statistic and Options
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// ErrGameNotFound is wrapped by the error for a game that is neither in
+// the Recent list nor has a section
+var ErrGameNotFound = errors.New("game not found")
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Game returns the game with the specified name, which may be a display
+// name, a name from the Recent list, or a section name. A game in the
+// Recent list with no statistics has zero statistics, and HasStats is
+// false.
+func (pdp *DataProvider) Game(name string) (*Game, error) {
+	sName := ToSectionName(ToDisplayName(name))
+	section, ok := pdp.Sections[sName]
+	if !ok && !pdp.isRecent(sName) {
+		return nil, fmt.Errorf("%w: %q", ErrGameNotFound, name)
+	}
+	game := &Game{
+		Section: sName,
+		Name:    ToDisplayName(sName),
+		Stats:   NewStatistics(0, 0, 0, 0),
+		Other:   map[string]string{},
+	}
+	for key, value := range section {
+		switch key {
+		case StatsKey:
+			ps, err := NewStatisticsFromString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", sName, err)
+			}
+			game.Stats = ps
+			game.HasStats = true
+		case OptionsKey:
+			game.Options = value
+		default:
+			game.Other[key] = value
+		}
+	}
+	return game, nil
+}
+
+// Games returns every game in the order of Inventory, or the first
+// error in reading them
+func (pdp *DataProvider) Games() ([]*Game, error) {
+	games := []*Game{}
+	err := pdp.Each(func(game *Game) error {
+		games = append(games, game)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// Each calls fn for every game in the order of Inventory. It stops at
+// the first error in reading a game or returned by fn, and returns it.
+func (pdp *DataProvider) Each(fn func(game *Game) error) error {
+	for _, item := range pdp.Inventory() {
+		game, err := pdp.Game(item.Section)
+		if err != nil {
+			return err
+		}
+		if err := fn(game); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Option returns true if option i, counting from zero, is chosen. It is
+// false for every option if the Options value is not a number.
+func (game *Game) Option(i int) bool {
+	options, err := strconv.ParseUint(game.Options, 10, 32)
+	return err == nil && i >= 0 && i < 32 && options&(1<<i) != 0
+}
+
+// isRecent returns true if the game is in the Recent list
+func (pdp *DataProvider) isRecent(sName string) bool {
+	for _, gameName := range pdp.GameList() {
+		if ToSectionName(ToDisplayName(gameName)) == sName {
+			return true
+		}
+	}
+	return false
+}
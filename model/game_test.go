@@ -0,0 +1,111 @@
+package model
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataProvider_Game(t *testing.T) {
+	pdp, err := NewDataProviderFromReader(strings.NewReader(`
+[Aisleriot Config]
+Recent=spider;block-ten;freecell;
+
+[freecell.scm]
+Statistic=1;2;60;60;
+
+[spider.scm]
+Statistic=45;244;479;907;
+Options=5
+Variation=2
+
+[klondike.scm]
+Statistic=5;4;60;90;
+
+[yukon.scm]
+Statistic=3;4;90;120;
+Options=bogus
+`))
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		section  string
+		total    int
+		hasStats bool
+		options  string
+		other    map[string]string
+		wantErr  string
+	}{
+		{"Spider", "spider.scm", 244, true, "5", map[string]string{"Variation": "2"}, ""},
+		{"freecell.scm", "freecell.scm", 2, true, "", map[string]string{}, ""},
+		{"block-ten", "block_ten.scm", 0, false, "", map[string]string{}, ""},
+		{"klondike", "", 0, false, "", nil, "wins (5) greater than total (4)"},
+		{"yukon", "yukon.scm", 4, true, "bogus", map[string]string{}, ""},
+		{"bogus", "", 0, false, "", nil, "game not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := pdp.Game(tt.name)
+			if tt.wantErr != "" {
+				assert.Nil(t, game)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.section, game.Section)
+			assert.Equal(t, ToDisplayName(tt.section), game.Name)
+			assert.Equal(t, tt.total, game.Stats.Total())
+			assert.Equal(t, tt.hasStats, game.HasStats)
+			assert.Equal(t, tt.options, game.Options)
+			assert.Equal(t, tt.other, game.Other)
+		})
+	}
+	_, err = pdp.Game("bogus")
+	assert.True(t, errors.Is(err, ErrGameNotFound))
+}
+
+func TestDataProvider_Games(t *testing.T) {
+	pdp, err := NewDataProvider(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	games, err := pdp.Games()
+	assert.Nil(t, err)
+	names := []string{}
+	for _, game := range games {
+		names = append(names, game.Name)
+	}
+	assert.Equal(t, []string{"Spider", "Freecell", "Canfield", "Klondike"}, names)
+
+	// Each stops at the first error from the function
+	stop := errors.New("stop")
+	count := 0
+	err = pdp.Each(func(game *Game) error {
+		count++
+		if game.Name == "Freecell" {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 2, count)
+
+	pdp, err = NewDataProviderFromReader(strings.NewReader("[freecell.scm]\nStatistic=1;2;\n"))
+	assert.Nil(t, err)
+	games, err = pdp.Games()
+	assert.Nil(t, games)
+	assert.NotNil(t, err)
+}
+
+func TestGame_Option(t *testing.T) {
+	game := &Game{Options: "5"}
+	for i, want := range []bool{true, false, true, false} {
+		assert.Equal(t, want, game.Option(i))
+	}
+	assert.False(t, game.Option(-1))
+	assert.False(t, game.Option(32))
+
+	game = &Game{Options: "bogus"}
+	assert.False(t, game.Option(0))
+}
@@ -0,0 +1,410 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Snapshot is a copy of the statistics for every game at one time
+type Snapshot struct {
+	Time   time.Time              // When the statistics file was written
+	Stats  map[string]*Statistics // Section name to statistics
+	Resets []string               // Games reset since the previous snapshot
+}
+
+// History is a list of snapshots in time order. It is kept in a file in
+// .ini format, with one section per snapshot named by its time, e.g.:
+//
+//	[2024-03-01T20:15:00Z]
+//	freecell.scm=175;209;88;406;
+//	spider.scm=55;275;479;907;
+//
+// A snapshot in which games had been reset lists them with ResetKey,
+// e.g., "reset=spider.scm;".
+type History struct {
+	Filename  string      // Where the history is kept
+	Snapshots []*Snapshot // Oldest first
+}
+
+// Era is the statistics for one game from the first snapshot after a
+// reset to the last snapshot before the next one
+type Era struct {
+	Start time.Time
+	End   time.Time
+	Stats *Statistics
+}
+
+// Sample is the statistics for one game in one snapshot
+type Sample struct {
+	Time  time.Time
+	Stats *Statistics
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+// SnapshotTimeFormat is the format of the section names in the history
+// file
+const SnapshotTimeFormat = time.RFC3339
+
+// ResetKey lists the games that were reset in a snapshot in the history
+// file
+const ResetKey = "reset"
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// reNameTime finds a date, and optionally a time, in a file name, e.g.,
+// "aisleriot-2024-03-01", "aisleriot.20240301", or
+// "aisleriot_2024-03-01T20-15"
+var reNameTime = regexp.MustCompile(`(\d{4})-?(\d{2})-?(\d{2})(?:[T_ -]?(\d{2})[:-]?(\d{2})(?:[:-]?(\d{2}))?)?`)
+
+// ---------------------------------------------------------------------
+// Constructors
+// ---------------------------------------------------------------------
+
+// NewSnapshot copies the statistics for every game in the data provider
+func NewSnapshot(t time.Time, pdp *DataProvider) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Time:  t.UTC().Truncate(time.Second),
+		Stats: map[string]*Statistics{},
+	}
+	for _, sName := range pdp.StatsSections() {
+		game, err := pdp.Game(sName)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Stats[sName] = game.Stats
+	}
+	return snapshot, nil
+}
+
+// NewHistory reads the specified history file, or the default one if
+// none is specified. A missing file is not an error; it gives an empty
+// history.
+func NewHistory(filenames ...string) (*History, error) {
+	var filename string
+	switch len(filenames) {
+	case 0:
+		filename = DefaultHistoryFileName()
+	default:
+		filename = filenames[0]
+	}
+	history := &History{Filename: filename, Snapshots: []*Snapshot{}}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sm, err := ParseData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	for sectionName, section := range sm {
+		t, err := time.Parse(SnapshotTimeFormat, sectionName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid snapshot time %q", filename, sectionName)
+		}
+		snapshot := &Snapshot{Time: t, Stats: map[string]*Statistics{}}
+		for sName, value := range section {
+			if sName == ResetKey {
+				snapshot.Resets = splitList(value)
+				continue
+			}
+			ps, err := NewStatisticsFromString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: [%s] %s: %v", filename, sectionName, sName, err)
+			}
+			snapshot.Stats[sName] = ps
+		}
+		history.Snapshots = append(history.Snapshots, snapshot)
+	}
+	history.sort()
+	return history, nil
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Equal returns true if both snapshots have the same statistics
+func (s *Snapshot) Equal(other *Snapshot) bool {
+	if len(s.Stats) != len(other.Stats) {
+		return false
+	}
+	for sName, ps := range s.Stats {
+		if ops, ok := other.Stats[sName]; !ok || *ops != *ps {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns the change in each game from this snapshot to
+// other. A game that is missing from one of them has zero statistics
+// there.
+func (s *Snapshot) Compare(other *Snapshot) map[string]Delta {
+	zero := NewStatistics(0, 0, 0, 0)
+	deltas := map[string]Delta{}
+	for sName, ps := range s.Stats {
+		ops, ok := other.Stats[sName]
+		if !ok {
+			ops = zero
+		}
+		deltas[sName] = ps.Compare(ops)
+	}
+	for sName, ops := range other.Stats {
+		if _, ok := s.Stats[sName]; !ok {
+			deltas[sName] = zero.Compare(ops)
+		}
+	}
+	return deltas
+}
+
+// Reset returns true if the game was reset in the snapshot
+func (s *Snapshot) Reset(sName string) bool {
+	for _, name := range s.Resets {
+		if name == sName {
+			return true
+		}
+	}
+	return false
+}
+
+// Add inserts a snapshot in time order. It returns false and does not
+// add it if its statistics are the same as those of the snapshot before
+// it, so that a history can be updated as often as desired. A snapshot
+// with the same time as one already in the history replaces it, since
+// the history file has one section for each time.
+func (h *History) Add(snapshot *Snapshot) bool {
+	i := sort.Search(len(h.Snapshots), func(i int) bool {
+		return h.Snapshots[i].Time.After(snapshot.Time)
+	})
+	if i > 0 && h.Snapshots[i-1].Equal(snapshot) {
+		return false
+	}
+	if i > 0 && h.Snapshots[i-1].Time.Equal(snapshot.Time) {
+		i--
+	} else {
+		h.Snapshots = append(h.Snapshots, nil)
+		copy(h.Snapshots[i+1:], h.Snapshots[i:])
+	}
+	h.Snapshots[i] = snapshot
+	h.markResets(i)
+	if i+1 < len(h.Snapshots) {
+		h.markResets(i + 1)
+	}
+	return true
+}
+
+// MarkResets sets the Resets of every snapshot to the games whose
+// statistics went down since the snapshot before it, which means that
+// they were reset or the statistics file was replaced. It returns the
+// snapshots with resets.
+func (h *History) MarkResets() []*Snapshot {
+	marked := []*Snapshot{}
+	for i, snapshot := range h.Snapshots {
+		if h.markResets(i) {
+			marked = append(marked, snapshot)
+		}
+	}
+	return marked
+}
+
+// markResets sets the Resets of the snapshot at index i and returns
+// true if there are any
+func (h *History) markResets(i int) bool {
+	snapshot := h.Snapshots[i]
+	snapshot.Resets = nil
+	if i == 0 {
+		return false
+	}
+	for sName, d := range h.Snapshots[i-1].Compare(snapshot) {
+		if d.Decreased() {
+			snapshot.Resets = append(snapshot.Resets, sName)
+		}
+	}
+	sort.Strings(snapshot.Resets)
+	return len(snapshot.Resets) > 0
+}
+
+// Eras returns the statistics for one game between resets, oldest
+// first. Each era holds the statistics of its last snapshot.
+func (h *History) Eras(sName string) []*Era {
+	eras := []*Era{}
+	var era *Era
+	for i, sample := range h.Series(sName) {
+		if era == nil || h.Snapshots[i].Reset(sName) {
+			era = &Era{Start: sample.Time}
+			eras = append(eras, era)
+		}
+		era.End = sample.Time
+		era.Stats = sample.Stats
+	}
+	return eras
+}
+
+// Lifetime returns the statistics for one game across all of its eras
+func (h *History) Lifetime(sName string) *Statistics {
+	stats := []*Statistics{}
+	for _, era := range h.Eras(sName) {
+		stats = append(stats, era.Stats)
+	}
+	return Total(stats...)
+}
+
+// Latest returns the most recent snapshot, or nil if there are none
+func (h *History) Latest() *Snapshot {
+	if len(h.Snapshots) == 0 {
+		return nil
+	}
+	return h.Snapshots[len(h.Snapshots)-1]
+}
+
+// Before returns the last snapshot taken before t, or nil if there is
+// none
+func (h *History) Before(t time.Time) *Snapshot {
+	i := sort.Search(len(h.Snapshots), func(i int) bool {
+		return !h.Snapshots[i].Time.Before(t)
+	})
+	if i == 0 {
+		return nil
+	}
+	return h.Snapshots[i-1]
+}
+
+// Sections returns the sorted names of all games in any snapshot
+func (h *History) Sections() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, snapshot := range h.Snapshots {
+		for sName := range snapshot.Stats {
+			if !seen[sName] {
+				seen[sName] = true
+				names = append(names, sName)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Series returns the statistics for one game in every snapshot, oldest
+// first. A game that is missing from a snapshot had not been played
+// yet, so it has zero statistics there.
+func (h *History) Series(sName string) []Sample {
+	samples := []Sample{}
+	for _, snapshot := range h.Snapshots {
+		ps, ok := snapshot.Stats[sName]
+		if !ok {
+			ps = NewStatistics(0, 0, 0, 0)
+		}
+		samples = append(samples, Sample{Time: snapshot.Time, Stats: ps})
+	}
+	return samples
+}
+
+// Save writes the history file, creating its directory if necessary.
+// The file is replaced only after the new one is completely written.
+func (h *History) Save() error {
+	var buf bytes.Buffer
+	for _, snapshot := range h.Snapshots {
+		fmt.Fprintf(&buf, "[%s]\n", snapshot.Time.UTC().Format(SnapshotTimeFormat))
+		sNames := []string{}
+		for sName := range snapshot.Stats {
+			sNames = append(sNames, sName)
+		}
+		sort.Strings(sNames)
+		for _, sName := range sNames {
+			fmt.Fprintf(&buf, "%s=%s\n", sName, snapshot.Stats[sName])
+		}
+		if len(snapshot.Resets) > 0 {
+			fmt.Fprintf(&buf, "%s=%s;\n", ResetKey, strings.Join(snapshot.Resets, ";"))
+		}
+		fmt.Fprintln(&buf)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.Filename), 0o755); err != nil {
+		return err
+	}
+	tmp := h.Filename + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.Filename)
+}
+
+// sort puts the snapshots in time order
+func (h *History) sort() {
+	sort.Slice(h.Snapshots, func(i, j int) bool {
+		return h.Snapshots[i].Time.Before(h.Snapshots[j].Time)
+	})
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// TimeFromName returns the date and time in a file name, in the local
+// time zone, e.g., 2024-03-01 20:15 for "aisleriot-2024-03-01T20-15.gz".
+// Only the base name is searched, and for an archive member, only the
+// name of the archive. A date without a time means midnight.
+func TimeFromName(name string) (time.Time, bool) {
+	if i := strings.LastIndex(name, MemberSeparator); i >= 0 {
+		if _, err := os.Stat(name); err != nil {
+			name = name[:i]
+		}
+	}
+	m := reNameTime.FindStringSubmatch(filepath.Base(name))
+	if m == nil {
+		return time.Time{}, false
+	}
+	n := make([]int, 6)
+	for i, s := range m[1:] {
+		n[i], _ = strconv.Atoi(s)
+	}
+	t := time.Date(n[0], time.Month(n[1]), n[2], n[3], n[4], n[5], 0, time.Local)
+	if t.Year() != n[0] || int(t.Month()) != n[1] || t.Day() != n[2] ||
+		t.Hour() != n[3] || t.Minute() != n[4] || t.Second() != n[5] {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// DefaultHistoryFileName returns the name of the history file in the
+// user data directory, which is $XDG_DATA_HOME or ~/.local/share.
+func DefaultHistoryFileName() string {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		homeDir, _ := os.UserHomeDir()
+		return HomeHistoryFileName(homeDir)
+	}
+	return historyFileIn(dataDir)
+}
+
+// HomeHistoryFileName returns the name of the history file for another
+// user whose home directory is homeDir
+func HomeHistoryFileName(homeDir string) string {
+	return historyFileIn(filepath.Join(homeDir, ".local", "share"))
+}
+
+// historyFileIn returns the name of the history file in a data directory
+func historyFileIn(dataDir string) string {
+	return filepath.Join(dataDir, "arstats", "history")
+}
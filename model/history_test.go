@@ -0,0 +1,203 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	pdp, err := NewDataProvider(filepath.Join(testdata, "aisleriot"))
+	assert.Nil(t, err)
+	snapshot, err := NewSnapshot(time.Date(2024, 3, 1, 20, 15, 0, 0, time.UTC), pdp)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(snapshot.Stats))
+	assert.Equal(t, "175;209;88;406;", snapshot.Stats["freecell.scm"].String())
+}
+
+func TestNewHistoryMissing(t *testing.T) {
+	history, err := NewHistory(filepath.Join(t.TempDir(), "history"))
+	assert.Nil(t, err)
+	assert.Empty(t, history.Snapshots)
+	assert.Nil(t, history.Latest())
+}
+
+func TestNewHistoryInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "history")
+	err := os.WriteFile(filename, []byte("[yesterday]\nfreecell.scm=1;2;3;4;\n"), 0o644)
+	assert.Nil(t, err)
+	_, err = NewHistory(filename)
+	assert.ErrorContains(t, err, `invalid snapshot time "yesterday"`)
+}
+
+func TestHistory_AddAndSave(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "arstats", "history")
+	history, err := NewHistory(filename)
+	assert.Nil(t, err)
+
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+	assert.True(t, history.Add(newTestSnapshot(t3, "spider.scm", "2;4;100;200;")))
+	assert.True(t, history.Add(newTestSnapshot(t1, "freecell.scm", "1;1;60;60;")))
+	assert.False(t, history.Add(newTestSnapshot(t2, "freecell.scm", "1;1;60;60;")))
+	assert.Equal(t, 2, len(history.Snapshots))
+	assert.Equal(t, t3, history.Latest().Time)
+	assert.Nil(t, history.Save())
+
+	history, err = NewHistory(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history.Snapshots))
+	assert.Equal(t, t1, history.Snapshots[0].Time)
+	assert.Equal(t, []string{"freecell.scm", "spider.scm"}, history.Sections())
+
+	series := history.Series("spider.scm")
+	assert.Equal(t, 2, len(series))
+	assert.Equal(t, 0, series[0].Stats.Total())
+	assert.Equal(t, 4, series[1].Stats.Total())
+}
+
+func TestHistory_AddSameTime(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "history")
+	history, err := NewHistory(filename)
+	assert.Nil(t, err)
+
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	assert.True(t, history.Add(newTestSnapshot(t1, "freecell.scm", "1;1;60;60;")))
+	assert.True(t, history.Add(newTestSnapshot(t2, "freecell.scm", "1;2;60;60;")))
+	assert.True(t, history.Add(newTestSnapshot(t2, "freecell.scm", "1;3;60;60;")))
+	assert.Equal(t, 2, len(history.Snapshots))
+	assert.Nil(t, history.Save())
+
+	history, err = NewHistory(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history.Snapshots))
+	assert.Equal(t, t2, history.Latest().Time)
+	assert.Equal(t, 3, history.Latest().Stats["freecell.scm"].Total())
+}
+
+func TestHistory_Before(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	history := &History{Snapshots: []*Snapshot{
+		newTestSnapshot(t1, "freecell.scm", "1;1;60;60;"),
+		newTestSnapshot(t2, "freecell.scm", "2;2;60;70;"),
+	}}
+	tests := []struct {
+		name string
+		t    time.Time
+		want *Snapshot
+	}{
+		{"before all", t1, nil},
+		{"between", t1.Add(time.Minute), history.Snapshots[0]},
+		{"at second", t2, history.Snapshots[0]},
+		{"after all", t2.Add(time.Minute), history.Snapshots[1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.want, history.Before(tt.t))
+		})
+	}
+}
+
+func TestSnapshot_Compare(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	before := newTestSnapshot(t1, "freecell.scm", "1;1;60;60;")
+	before.Stats["spider.scm"], _ = NewStatisticsFromString("0;2;0;0;")
+	after := newTestSnapshot(t1.Add(time.Hour), "freecell.scm", "2;3;60;70;")
+	after.Stats["klondike.scm"], _ = NewStatisticsFromString("1;1;100;100;")
+
+	deltas := before.Compare(after)
+	assert.Equal(t, 3, len(deltas))
+	assert.Equal(t, 2, deltas["freecell.scm"].Total)
+	assert.Equal(t, 10, deltas["freecell.scm"].Worst)
+	assert.Equal(t, -2, deltas["spider.scm"].Total)
+	assert.True(t, deltas["spider.scm"].Decreased())
+	assert.Equal(t, 1, deltas["klondike.scm"].Wins)
+	assert.Equal(t, 0, deltas["klondike.scm"].From.Total())
+}
+
+func TestHistory_MarkResets(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	history := &History{Filename: filepath.Join(t.TempDir(), "history")}
+	for i, statStrings := range [][]string{
+		{"5;10;60;90;", "1;2;300;300;"},
+		{"6;11;60;90;", "1;2;300;300;"},
+		{"1;1;80;80;", "0;0;0;0;"},
+		{"2;2;80;80;", "0;1;0;0;"},
+	} {
+		snapshot := newTestSnapshot(t1.Add(time.Duration(i)*time.Hour), "freecell.scm", statStrings[0])
+		snapshot.Stats["spider.scm"], _ = NewStatisticsFromString(statStrings[1])
+		history.Add(snapshot)
+	}
+	// Add marks the resets as the snapshots are added
+	assert.Equal(t, []string{"freecell.scm", "spider.scm"}, history.Snapshots[2].Resets)
+	marked := history.MarkResets()
+	assert.Equal(t, []*Snapshot{history.Snapshots[2]}, marked)
+	assert.Equal(t, []string{"freecell.scm", "spider.scm"}, history.Snapshots[2].Resets)
+
+	// The resets are kept in the history file
+	assert.Nil(t, history.Save())
+	history, err := NewHistory(history.Filename)
+	assert.Nil(t, err)
+	assert.Nil(t, history.Snapshots[1].Resets)
+	assert.Equal(t, []string{"freecell.scm", "spider.scm"}, history.Snapshots[2].Resets)
+	assert.Equal(t, 2, len(history.Snapshots[2].Stats))
+}
+
+func TestHistory_Eras(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	history := &History{}
+	for i, statString := range []string{
+		"5;10;60;90;",
+		"6;11;60;90;",
+		"1;1;50;50;",
+		"2;4;50;80;",
+	} {
+		history.Add(newTestSnapshot(t1.Add(time.Duration(i)*time.Hour), "freecell.scm", statString))
+	}
+	eras := history.Eras("freecell.scm")
+	assert.Equal(t, 2, len(eras))
+	assert.Equal(t, t1, eras[0].Start)
+	assert.Equal(t, t1.Add(time.Hour), eras[0].End)
+	assert.Equal(t, "6;11;60;90;", eras[0].Stats.String())
+	assert.Equal(t, t1.Add(2*time.Hour), eras[1].Start)
+	assert.Equal(t, t1.Add(3*time.Hour), eras[1].End)
+	assert.Equal(t, "2;4;50;80;", eras[1].Stats.String())
+	assert.Equal(t, "8;15;50;90;", history.Lifetime("freecell.scm").String())
+	assert.Empty(t, history.Eras("spider.scm")[0].Stats.Total())
+}
+
+func TestTimeFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want time.Time
+		ok   bool
+	}{
+		{"aisleriot-2024-03-01.gz", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), true},
+		{"/backups/aisleriot.20240301", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), true},
+		{"aisleriot_2024-03-01T20-15", time.Date(2024, 3, 1, 20, 15, 0, 0, time.Local), true},
+		{"aisleriot 2024-03-01 20:15:07.bz2", time.Date(2024, 3, 1, 20, 15, 7, 0, time.Local), true},
+		{"home-20240301.tar.gz#home/me/.config/gnome-games/aisleriot", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), true},
+		{"2024-03-01/aisleriot", time.Time{}, false},
+		{"aisleriot-2024-13-01", time.Time{}, false},
+		{"aisleriot", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, ok := TimeFromName(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.want.Equal(have), "%v", have)
+		})
+	}
+}
+
+// newTestSnapshot returns a snapshot with the statistics for one game
+func newTestSnapshot(t time.Time, sName, statString string) *Snapshot {
+	ps, _ := NewStatisticsFromString(statString)
+	return &Snapshot{Time: t, Stats: map[string]*Statistics{sName: ps}}
+}
@@ -0,0 +1,86 @@
+package model
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// GameStatus tells whether a game is in the Recent list and whether it
+// has statistics
+type GameStatus int
+
+// InventoryItem is one game in the statistics file
+type InventoryItem struct {
+	Name     string // As in the Recent list, e.g., "block-ten"
+	Section  string // e.g., "block_ten.scm"
+	Position int    // 1 for the game played most recently, or 0 if not in Recent
+	Status   GameStatus
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	GameRecent    GameStatus = iota // In the Recent list, with statistics
+	GameNotRecent                   // With statistics, but not in the Recent list
+	GameNoStats                     // In the Recent list, without statistics
+)
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// String returns "recent", "not-recent", or "no-stats"
+func (status GameStatus) String() string {
+	switch status {
+	case GameRecent:
+		return "recent"
+	case GameNotRecent:
+		return "not-recent"
+	case GameNoStats:
+		return "no-stats"
+	}
+	return "unknown"
+}
+
+// Inventory returns every game in the Recent list, most recent first,
+// followed by the other games with statistics, sorted by section name.
+// A game that is in the Recent list more than once is listed at its
+// first position.
+func (pdp *DataProvider) Inventory() []*InventoryItem {
+	items := []*InventoryItem{}
+	seen := map[string]bool{}
+	for _, gameName := range pdp.GameList() {
+		sName := ToSectionName(ToDisplayName(gameName))
+		if sName == "" || seen[sName] {
+			continue
+		}
+		seen[sName] = true
+		item := &InventoryItem{
+			Name:     gameName,
+			Section:  sName,
+			Position: len(items) + 1,
+			Status:   GameRecent,
+		}
+		if _, ok := pdp.Sections[sName][StatsKey]; !ok {
+			item.Status = GameNoStats
+		}
+		items = append(items, item)
+	}
+	for _, sName := range pdp.StatsSections() {
+		if seen[sName] {
+			continue
+		}
+		items = append(items, &InventoryItem{
+			Name:    ToRecentName(sName),
+			Section: sName,
+			Status:  GameNotRecent,
+		})
+	}
+	return items
+}
+
+// HasStats returns true if the game has statistics
+func (item *InventoryItem) HasStats() bool {
+	return item.Status != GameNoStats
+}
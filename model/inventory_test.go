@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataProvider_Inventory(t *testing.T) {
+	pdp, err := NewDataProviderFromReader(strings.NewReader(`
+[Aisleriot Config]
+Recent=spider;block-ten;freecell;spider;
+
+[freecell.scm]
+Statistic=1;2;60;60;
+
+[spider.scm]
+Statistic=0;1;0;0;
+
+[yukon.scm]
+Statistic=3;4;90;120;
+
+[klondike.scm]
+Options=2
+`))
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		section  string
+		position int
+		status   GameStatus
+	}{
+		{"spider", "spider.scm", 1, GameRecent},
+		{"block-ten", "block_ten.scm", 2, GameNoStats},
+		{"freecell", "freecell.scm", 3, GameRecent},
+		{"yukon", "yukon.scm", 0, GameNotRecent},
+	}
+	items := pdp.Inventory()
+	assert.Equal(t, len(tests), len(items))
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := items[i]
+			assert.Equal(t, tt.name, item.Name)
+			assert.Equal(t, tt.section, item.Section)
+			assert.Equal(t, tt.position, item.Position)
+			assert.Equal(t, tt.status, item.Status)
+			assert.Equal(t, tt.status != GameNoStats, item.HasStats())
+		})
+	}
+	assert.Equal(t, "no-stats", GameNoStats.String())
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Standing is one player's place in a ranking
+type Standing struct {
+	Rank   int // 1 for first; players who are tied have the same rank
+	Player string
+	Stats  *Statistics
+}
+
+// Leaderboard ranks players by winning percentage in each game and in
+// all games together. Players who have played too few games are left
+// out.
+type Leaderboard struct {
+	MinGames int                    // Games needed to be ranked in a game
+	MinTotal int                    // Games needed to be ranked overall
+	Games    map[string][]*Standing // Section name to standings, best first
+	Overall  []*Standing            // Standings for all games together
+}
+
+// ---------------------------------------------------------------------
+// Constructor
+// ---------------------------------------------------------------------
+
+// NewLeaderboard ranks the players in the shares. If a player has more
+// than one share, the latest is used. Players must have played at least
+// minGames of a game to be ranked in it, and minTotal games in all to be
+// ranked overall.
+func NewLeaderboard(shares []*Share, minGames, minTotal int) *Leaderboard {
+	latest := map[string]*Share{}
+	for _, share := range shares {
+		if prev, ok := latest[share.Player]; !ok || share.Time.After(prev.Time) {
+			latest[share.Player] = share
+		}
+	}
+	lb := &Leaderboard{
+		MinGames: minGames,
+		MinTotal: minTotal,
+		Games:    map[string][]*Standing{},
+		Overall:  []*Standing{},
+	}
+	for player, share := range latest {
+		all := []*Statistics{}
+		for sName, ps := range share.Stats() {
+			all = append(all, ps)
+			if ps.Total() >= minGames && ps.Total() > 0 {
+				lb.Games[sName] = append(lb.Games[sName], &Standing{Player: player, Stats: ps})
+			}
+		}
+		if ps := Total(all...); ps.Total() >= minTotal && ps.Total() > 0 {
+			lb.Overall = append(lb.Overall, &Standing{Player: player, Stats: ps})
+		}
+	}
+	for _, standings := range lb.Games {
+		rank(standings)
+	}
+	rank(lb.Overall)
+	return lb
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Sections returns the sorted names of the games with ranked players
+func (lb *Leaderboard) Sections() []string {
+	sNames := []string{}
+	for sName := range lb.Games {
+		sNames = append(sNames, sName)
+	}
+	sort.Strings(sNames)
+	return sNames
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// rank sorts the standings by winning percentage, then by wins, then by
+// best time, and numbers them. Players with the same percentage and
+// wins share a rank.
+func rank(standings []*Standing) {
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if cmp := compareStandings(a, b); cmp != 0 {
+			return cmp < 0
+		}
+		if a.Stats.HasTimes() != b.Stats.HasTimes() {
+			return a.Stats.HasTimes()
+		}
+		if a.Stats.Best() != b.Stats.Best() {
+			return a.Stats.Best() < b.Stats.Best()
+		}
+		return strings.ToLower(a.Player) < strings.ToLower(b.Player)
+	})
+	for i, standing := range standings {
+		standing.Rank = i + 1
+		if i > 0 && compareStandings(standings[i-1], standing) == 0 {
+			standing.Rank = standings[i-1].Rank
+		}
+	}
+}
+
+// compareStandings returns a negative number if a ranks above b by
+// percentage and wins, a positive number if below, and zero if tied
+func compareStandings(a, b *Standing) int {
+	pa, pb := a.Stats.ExactPercentage(), b.Stats.ExactPercentage()
+	switch {
+	case pa > pb:
+		return -1
+	case pa < pb:
+		return 1
+	}
+	return b.Stats.Wins() - a.Stats.Wins()
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLeaderboard(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	share := func(player string, t time.Time, stats map[string]string) *Share {
+		snapshot := &Snapshot{Time: t, Stats: map[string]*Statistics{}}
+		for sName, statString := range stats {
+			snapshot.Stats[sName], _ = NewStatisticsFromString(statString)
+		}
+		return NewShare(player, snapshot)
+	}
+	shares := []*Share{
+		share("alice", t1, map[string]string{"freecell.scm": "8;10;60;90;", "spider.scm": "1;20;400;400;"}),
+		share("bob", t1, map[string]string{"freecell.scm": "9;10;70;90;", "spider.scm": "2;5;300;500;"}),
+		share("bob", t1.Add(-time.Hour), map[string]string{"freecell.scm": "1;1;50;50;"}),
+		share("carol", t1, map[string]string{"freecell.scm": "8;10;50;95;"}),
+	}
+	lb := NewLeaderboard(shares, 10, 15)
+
+	standings := func(list []*Standing) []string {
+		s := []string{}
+		for _, standing := range list {
+			s = append(s, string(rune('0'+standing.Rank))+" "+standing.Player)
+		}
+		return s
+	}
+	assert.Equal(t, []string{"freecell.scm", "spider.scm"}, lb.Sections())
+	assert.Equal(t, []string{"1 bob", "2 carol", "2 alice"}, standings(lb.Games["freecell.scm"]))
+	assert.Equal(t, []string{"1 alice"}, standings(lb.Games["spider.scm"]))
+	assert.Equal(t, []string{"1 bob", "2 alice"}, standings(lb.Overall))
+	assert.Equal(t, "11;15;70;500;", lb.Overall[0].Stats.String())
+}
@@ -0,0 +1,69 @@
+package model
+
+import "sort"
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// MilestoneKind is the kind of event worth telling the player about
+type MilestoneKind int
+
+// Milestone is something that happened in one game between two
+// snapshots
+type Milestone struct {
+	Kind    MilestoneKind
+	Section string      // Section name of the game
+	Before  *Statistics // Statistics in the earlier snapshot
+	After   *Statistics // Statistics in the later snapshot
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	MilestoneBestTime   MilestoneKind = iota // A new best time
+	MilestonePercentUp                       // The winning percentage went up
+	MilestoneGamesTotal                      // The games played reached a multiple of MilestoneEvery
+)
+
+// MilestoneEvery is the number of games played between milestones
+const MilestoneEvery = 100
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// Milestones compares two snapshots and returns the milestones reached
+// in each game, in order of section name. Nothing is reported for a
+// game whose statistics were reset.
+func Milestones(before, after *Snapshot) []*Milestone {
+	sNames := []string{}
+	for sName := range after.Stats {
+		sNames = append(sNames, sName)
+	}
+	sort.Strings(sNames)
+
+	deltas := before.Compare(after)
+	milestones := []*Milestone{}
+	for _, sName := range sNames {
+		d := deltas[sName]
+		if d.Total <= 0 || d.Decreased() {
+			continue
+		}
+		add := func(kind MilestoneKind) {
+			milestones = append(milestones, &Milestone{kind, sName, d.From, d.To})
+		}
+		if d.To.HasTimes() && (!d.From.HasTimes() || d.Best < 0) {
+			add(MilestoneBestTime)
+		}
+		if d.From.Total() > 0 && d.To.Percentage() > d.From.Percentage() {
+			add(MilestonePercentUp)
+		}
+		if d.To.Total()/MilestoneEvery > d.From.Total()/MilestoneEvery {
+			add(MilestoneGamesTotal)
+		}
+	}
+	return milestones
+}
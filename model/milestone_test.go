@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMilestones(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []MilestoneKind
+	}{
+		{"no new games", "5;10;60;90;", "5;10;60;90;", []MilestoneKind{}},
+		{"loss", "5;10;60;90;", "5;11;60;90;", []MilestoneKind{}},
+		{"win, no new best", "5;10;60;90;", "6;11;70;90;", []MilestoneKind{MilestonePercentUp}},
+		{"new best time", "5;10;60;90;", "6;11;50;90;", []MilestoneKind{MilestoneBestTime, MilestonePercentUp}},
+		{"first time", "0;10;0;0;", "0;10;0;0;", []MilestoneKind{}},
+		{"first win", "0;10;0;0;", "1;11;80;80;", []MilestoneKind{MilestoneBestTime, MilestonePercentUp}},
+		{"hundredth game", "50;99;60;90;", "50;100;60;90;", []MilestoneKind{MilestoneGamesTotal}},
+		{"reset", "50;99;60;90;", "1;1;60;60;", []MilestoneKind{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := newTestSnapshot(t0, "klondike", tt.before)
+			after := newTestSnapshot(t0.Add(time.Minute), "klondike", tt.after)
+			have := []MilestoneKind{}
+			for _, m := range Milestones(before, after) {
+				assert.Equal(t, "klondike", m.Section)
+				have = append(have, m.Kind)
+			}
+			assert.Equal(t, tt.want, have)
+		})
+	}
+}
+
+func TestMilestones_NewGame(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	before := newTestSnapshot(t0, "klondike", "5;10;60;90;")
+	after := newTestSnapshot(t0.Add(time.Minute), "klondike", "5;10;60;90;")
+	after.Stats["freecell"], _ = NewStatisticsFromString("1;1;100;100;")
+	milestones := Milestones(before, after)
+	if assert.Len(t, milestones, 1) {
+		assert.Equal(t, "freecell", milestones[0].Section)
+		assert.Equal(t, MilestoneBestTime, milestones[0].Kind)
+		assert.Equal(t, 0, milestones[0].Before.Total())
+	}
+}
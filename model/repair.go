@@ -0,0 +1,204 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Repair is a damaged statistics file made readable again
+type Repair struct {
+	Data     []byte   // The repaired file
+	Changes  []string // What was changed, in the order of the file
+	Problems []string // Impossible statistics, which are not repaired
+}
+
+// repairSection is a section of a file being repaired, with its lines
+type repairSection struct {
+	name  string
+	lines []string
+	stats bool // true if it has a Statistic item
+}
+
+// ---------------------------------------------------------------------
+// Constructors
+// ---------------------------------------------------------------------
+
+// RepairData repairs the contents of a statistics file, keeping every
+// section that can be read:
+//   - Lines that are not section headers or items, including items
+//     with more than one "=", and items that are not in a section, are
+//     removed.
+//   - Whitespace is removed from Statistic items, and a missing
+//     trailing ";" is added.
+//   - Sections whose Statistic item still cannot be parsed are removed.
+//   - If there is no Recent item, it is rebuilt from the sections with
+//     statistics, in the order of the file.
+//
+// Statistics that can be read but that Aisleriot could not have written
+// are left as they were and reported in Problems.
+//
+// Blank lines, comments, and items that are not damaged are left as
+// they were.
+func RepairData(data []byte) *Repair {
+	r := &Repair{}
+	preamble := []string{}
+	sections := []*repairSection{}
+	var section *repairSection
+	dropping := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			if section == nil {
+				preamble = append(preamble, line)
+			} else if !dropping {
+				section.lines = append(section.lines, line)
+			}
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			group := reSection.FindStringSubmatch(trimmed)
+			if group == nil || group[0] != trimmed || strings.TrimSpace(group[1]) == "" {
+				r.changef("line %d: removed invalid section header %q", lineNumber, trimmed)
+				dropping = true
+				continue
+			}
+			section = &repairSection{name: group[1], lines: []string{trimmed}}
+			sections = append(sections, section)
+			dropping = false
+			continue
+		}
+		if section == nil || dropping {
+			r.changef("line %d: removed %q, which is not in a section", lineNumber, trimmed)
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" || strings.Contains(value, "=") {
+			r.changef("line %d: removed invalid line %q", lineNumber, trimmed)
+			continue
+		}
+		if key == StatsKey {
+			value = strings.Join(strings.Fields(value), "")
+			if !strings.HasSuffix(value, ";") {
+				value += ";"
+			}
+			section.stats = true
+		}
+		item := key + "=" + value
+		if item != line {
+			r.changef("line %d: changed %q to %q", lineNumber, line, item)
+		}
+		section.lines = append(section.lines, item)
+	}
+
+	// Remove the sections whose statistics cannot be parsed
+	kept := []*repairSection{}
+	var header *repairSection
+	for _, section := range sections {
+		if section.stats {
+			ps, err := ParseStatistics(section.statistic())
+			if err != nil {
+				r.changef("[%s]: removed the section, because its statistics cannot be read: %v", section.name, err)
+				continue
+			}
+			if err := ps.Validate(); err != nil {
+				for _, problem := range strings.Split(err.Error(), "\n") {
+					r.Problems = append(r.Problems, fmt.Sprintf("%s: %s", section.name, problem))
+				}
+			}
+		}
+		if section.name == HeaderSection {
+			header = section
+		}
+		kept = append(kept, section)
+	}
+
+	// Rebuild the list of recent games if it is missing
+	if header == nil || !header.has(RecentItem) {
+		games := []string{}
+		for _, section := range kept {
+			if section.stats {
+				games = append(games, ToRecentName(section.name))
+			}
+		}
+		if len(games) > 0 {
+			if header == nil {
+				header = &repairSection{name: HeaderSection, lines: []string{"[" + HeaderSection + "]", ""}}
+				kept = append([]*repairSection{header}, kept...)
+			}
+			header.add(RecentItem + "=" + strings.Join(games, ";") + ";")
+			noun := "games"
+			if len(games) == 1 {
+				noun = "game"
+			}
+			r.changef("[%s]: rebuilt %s from %d %s", HeaderSection, RecentItem, len(games), noun)
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, line := range preamble {
+		fmt.Fprintln(&buf, line)
+	}
+	for _, section := range kept {
+		for _, line := range section.lines {
+			fmt.Fprintln(&buf, line)
+		}
+	}
+	r.Data = buf.Bytes()
+	return r
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Changed returns true if anything was repaired
+func (r *Repair) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// changef records a change
+func (r *Repair) changef(format string, args ...any) {
+	r.Changes = append(r.Changes, fmt.Sprintf(format, args...))
+}
+
+// statistic returns the value of the last Statistic item in the section
+func (section *repairSection) statistic() string {
+	value := ""
+	for _, line := range section.lines[1:] {
+		if key, v, ok := strings.Cut(line, "="); ok && key == StatsKey {
+			value = v
+		}
+	}
+	return value
+}
+
+// has returns true if the section has an item with the key
+func (section *repairSection) has(key string) bool {
+	for _, line := range section.lines[1:] {
+		if k, _, ok := strings.Cut(line, "="); ok && strings.TrimSpace(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds an item after the last line of the section that is not blank
+func (section *repairSection) add(item string) {
+	i := len(section.lines)
+	for i > 1 && strings.TrimSpace(section.lines[i-1]) == "" {
+		i--
+	}
+	section.lines = append(section.lines, "")
+	copy(section.lines[i+1:], section.lines[i:])
+	section.lines[i] = item
+}
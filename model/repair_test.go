@@ -0,0 +1,109 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepairData(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		changes int
+	}{
+		{
+			name: "nothing to repair",
+			data: "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want: "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+		},
+		{
+			name:    "statistic whitespace and semicolon",
+			data:    "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\n  Statistic = 1; 2;60 ;60\n",
+			want:    "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 1,
+		},
+		{
+			name:    "invalid lines",
+			data:    "oops\n[Aisleriot Config]\nRecent=freecell;\nWhat up?\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want:    "[Aisleriot Config]\nRecent=freecell;\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 2,
+		},
+		{
+			name:    "more than one equals sign",
+			data:    "[Aisleriot Config]\nRecent=freecell;\nSound==false\nTheme=a=b\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want:    "[Aisleriot Config]\nRecent=freecell;\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 2,
+		},
+		{
+			name:    "invalid section header",
+			data:    "[Aisleriot Config]\nRecent=freecell;\n[klondike.scm\nStatistic=1;2;60;60;\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want:    "[Aisleriot Config]\nRecent=freecell;\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 2,
+		},
+		{
+			name:    "unreadable statistics",
+			data:    "[Aisleriot Config]\nRecent=freecell;\n\n[klondike.scm]\nStatistic=1;2;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want:    "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 1,
+		},
+		{
+			name:    "missing recent",
+			data:    "[Aisleriot Config]\nSound=false\n\n[spider.scm]\nStatistic=0;1;0;0;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want:    "[Aisleriot Config]\nSound=false\nRecent=spider;freecell;\n\n[spider.scm]\nStatistic=0;1;0;0;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 1,
+		},
+		{
+			name:    "missing recent with underscores",
+			data:    "[Aisleriot Config]\n\n[block_ten.scm]\nStatistic=0;1;0;0;\n",
+			want:    "[Aisleriot Config]\nRecent=block-ten;\n\n[block_ten.scm]\nStatistic=0;1;0;0;\n",
+			changes: 1,
+		},
+		{
+			name:    "missing header",
+			data:    "[freecell.scm]\nStatistic=1;2;60;60;\n",
+			want:    "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n",
+			changes: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RepairData([]byte(tt.data))
+			assert.Equal(t, tt.want, string(r.Data))
+			assert.Equal(t, tt.changes, len(r.Changes), strings.Join(r.Changes, "\n"))
+			assert.Equal(t, tt.changes > 0, r.Changed())
+			_, err := ParseData(r.Data)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestRepairData_impossible(t *testing.T) {
+	data := "[Aisleriot Config]\nRecent=freecell;\n\n[freecell.scm]\nStatistic=3;2;60;60;\n"
+	r := RepairData([]byte(data))
+	assert.False(t, r.Changed())
+	assert.Equal(t, data, string(r.Data))
+	assert.Equal(t, []string{"freecell.scm: impossible statistics: wins (3) greater than total (2)"}, r.Problems)
+
+	r = RepairData([]byte("[freecell.scm]\nStatistic=1;2;60;60;\n"))
+	assert.Equal(t, []string{"[Aisleriot Config]: rebuilt Recent from 1 game"}, r.Changes)
+	assert.Empty(t, r.Problems)
+}
+
+func TestRepairData_bogus2(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(testdata, "bogus2.ini"))
+	assert.Nil(t, err)
+	_, err = ParseData(data)
+	assert.NotNil(t, err)
+
+	r := RepairData(data)
+	assert.Equal(t, []string{`line 9: removed invalid line "What up?"`}, r.Changes)
+	pdp, err := NewDataProviderFromReader(strings.NewReader(string(r.Data)))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"spider", "freecell", "canfield", "klondike"}, pdp.GameList())
+	assert.Equal(t, "45;244;479;907;", pdp.Sections["spider.scm"][StatsKey])
+}
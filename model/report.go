@@ -0,0 +1,178 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Period is the length of time covered by each part of a report
+type Period int
+
+// Activity is what happened in one game during one period, found from
+// the differences between successive snapshots
+type Activity struct {
+	Start   time.Time // Start of the period
+	End     time.Time // Start of the next period
+	Section string    // Section name of the game
+	Wins    int       // Games won in the period
+	Losses  int       // Games lost in the period
+	NewBest int       // Best time set in the period, or 0 if none
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	PeriodDay Period = iota
+	PeriodWeek
+	PeriodMonth
+)
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+var periodNames = map[Period]string{
+	PeriodDay:   "day",
+	PeriodWeek:  "week",
+	PeriodMonth: "month",
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// String returns the name of the period, as accepted by ParsePeriod
+func (p Period) String() string {
+	return periodNames[p]
+}
+
+// Start returns the start of the period that contains t, in the time
+// zone of t. Weeks start on Monday.
+func (p Period) Start(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch p {
+	case PeriodWeek:
+		weekday := (int(t.Weekday()) + 6) % 7 // Monday is 0
+		return time.Date(year, month, day-weekday, 0, 0, 0, 0, t.Location())
+	case PeriodMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next returns the start of the period after the one that starts at
+// start
+func (p Period) Next(start time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Label returns a name for the period that starts at start, e.g.,
+// "2024-03-04", "2024-W10", or "2024-03"
+func (p Period) Label(start time.Time) string {
+	switch p {
+	case PeriodWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case PeriodMonth:
+		return start.Format("2006-01")
+	default:
+		return start.Format("2006-01-02")
+	}
+}
+
+// Played returns the number of games played in the period
+func (pa *Activity) Played() int {
+	return pa.Wins + pa.Losses
+}
+
+// Statistics returns the statistics for the period alone. The times
+// are the new best time, if any.
+func (pa *Activity) Statistics() *Statistics {
+	return NewStatistics(pa.Wins, pa.Played(), pa.NewBest, pa.NewBest)
+}
+
+// Activity returns what happened in each game in each period between
+// since and until, in order of period and then section name. A change
+// between two snapshots is counted in the period of the later one.
+// A zero since or until is not a limit. Periods are in the time zone of
+// loc. After a reset, the games are counted from zero.
+func (h *History) Activity(period Period, since, until time.Time, loc *time.Location) []*Activity {
+	type key struct {
+		start   time.Time
+		section string
+	}
+	byKey := map[key]*Activity{}
+	for _, sName := range h.Sections() {
+		samples := h.Series(sName)
+		for i := 1; i < len(samples); i++ {
+			t := samples[i].Time
+			if t.Before(since) || (!until.IsZero() && !t.Before(until)) {
+				continue
+			}
+			prev, next := samples[i-1].Stats, samples[i].Stats
+			wins, losses := next.GamesSince(prev)
+			if wins+losses == 0 {
+				continue
+			}
+			start := period.Start(t.In(loc))
+			k := key{start, sName}
+			pa, ok := byKey[k]
+			if !ok {
+				pa = &Activity{Start: start, End: period.Next(start), Section: sName}
+				byKey[k] = pa
+			}
+			pa.Wins += wins
+			pa.Losses += losses
+			reset := prev.Compare(next).Decreased()
+			if next.HasTimes() && (reset || !prev.HasTimes() || next.Best() < prev.Best()) {
+				if pa.NewBest == 0 || next.Best() < pa.NewBest {
+					pa.NewBest = next.Best()
+				}
+			}
+		}
+	}
+	activities := []*Activity{}
+	for _, pa := range byKey {
+		activities = append(activities, pa)
+	}
+	sort.Slice(activities, func(i, j int) bool {
+		a, b := activities[i], activities[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		return a.Section < b.Section
+	})
+	return activities
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// ParsePeriod returns the period with the specified name, which is one
+// of "day", "week", or "month".
+func ParsePeriod(name string) (Period, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for p, pName := range periodNames {
+		if name == pName {
+			return p, nil
+		}
+	}
+	return PeriodDay, fmt.Errorf("invalid period %q", name)
+}
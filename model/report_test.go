@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePeriod(t *testing.T) {
+	for _, name := range []string{"day", "week", "month"} {
+		p, err := ParsePeriod(name)
+		assert.Nil(t, err)
+		assert.Equal(t, name, p.String())
+	}
+	_, err := ParsePeriod("year")
+	assert.NotNil(t, err)
+}
+
+func TestPeriod_Start(t *testing.T) {
+	tm := time.Date(2024, 3, 7, 15, 30, 0, 0, time.UTC) // Thursday
+	tests := []struct {
+		name          string
+		period        Period
+		expectedStart time.Time
+		expectedNext  time.Time
+		expectedLabel string
+	}{
+		{"day", PeriodDay, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), "2024-03-07"},
+		{"week", PeriodWeek, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), "2024-W10"},
+		{"month", PeriodMonth, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), "2024-03"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := tt.period.Start(tm)
+			assert.Equal(t, tt.expectedStart, start)
+			assert.Equal(t, tt.expectedNext, tt.period.Next(start))
+			assert.Equal(t, tt.expectedLabel, tt.period.Label(start))
+		})
+	}
+}
+
+func TestHistory_Activity(t *testing.T) {
+	history := &History{}
+	day := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.UTC) }
+	history.Add(newTestSnapshot(day(1, 9), "freecell.scm", "10;20;100;300;"))
+	history.Add(newTestSnapshot(day(1, 12), "freecell.scm", "12;23;100;300;"))
+	history.Add(newTestSnapshot(day(2, 12), "freecell.scm", "13;24;90;300;"))
+	history.Add(newTestSnapshot(day(3, 12), "freecell.scm", "1;1;120;120;")) // Reset
+	history.Add(newTestSnapshot(day(9, 12), "freecell.scm", "0;1;0;0;"))     // Reset
+	history.Add(newTestSnapshot(day(10, 12), "freecell.scm", "1;2;200;200;"))
+
+	activities := history.Activity(PeriodDay, time.Time{}, time.Time{}, time.UTC)
+	assert.Equal(t, 5, len(activities))
+	assert.Equal(t, day(1, 0), activities[0].Start)
+	assert.Equal(t, 2, activities[0].Wins)
+	assert.Equal(t, 1, activities[0].Losses)
+	assert.Equal(t, 0, activities[0].NewBest)
+	assert.Equal(t, 90, activities[1].NewBest)
+
+	// The games after a reset are counted from zero
+	assert.Equal(t, day(3, 0), activities[2].Start)
+	assert.Equal(t, 1, activities[2].Wins)
+	assert.Equal(t, 0, activities[2].Losses)
+	assert.Equal(t, 120, activities[2].NewBest)
+	assert.Equal(t, 1, activities[3].Losses)
+	assert.Equal(t, 200, activities[4].NewBest)
+
+	activities = history.Activity(PeriodWeek, time.Time{}, day(3, 0), time.UTC)
+	assert.Equal(t, 1, len(activities))
+	assert.Equal(t, 4, activities[0].Played())
+	assert.Equal(t, 75, activities[0].Statistics().Percentage())
+
+	activities = history.Activity(PeriodMonth, day(2, 0), time.Time{}, time.UTC)
+	assert.Equal(t, 1, len(activities))
+	assert.Equal(t, 3, activities[0].Wins)
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Session is a time of continuous play, inferred from snapshots that
+// recorded games close together
+type Session struct {
+	Start  time.Time // Time of the first snapshot with a new game
+	End    time.Time // Time of the last snapshot with a new game
+	Wins   int
+	Losses int
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+// DefaultSessionGap is the longest time between two games in the same
+// session
+const DefaultSessionGap = 30 * time.Minute
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Played returns the number of games played in the session
+func (s *Session) Played() int {
+	return s.Wins + s.Losses
+}
+
+// Sessions groups the games recorded in the history into sessions. A
+// snapshot with new games that is no more than gap after the previous
+// one joins its session. Only the specified games are counted, or every
+// game if none are specified. After a reset, the games are counted from
+// zero.
+func (h *History) Sessions(gap time.Duration, sNames ...string) []*Session {
+	if len(sNames) == 0 {
+		sNames = h.Sections()
+	}
+	series := [][]Sample{}
+	for _, sName := range sNames {
+		series = append(series, h.Series(sName))
+	}
+	sessions := []*Session{}
+	var current *Session
+	for i := 1; i < len(h.Snapshots); i++ {
+		wins, losses := 0, 0
+		for _, samples := range series {
+			dw, dl := samples[i].Stats.GamesSince(samples[i-1].Stats)
+			wins += dw
+			losses += dl
+		}
+		if wins+losses == 0 {
+			continue
+		}
+		t := h.Snapshots[i].Time
+		if current == nil || t.Sub(current.End) > gap {
+			current = &Session{Start: t}
+			sessions = append(sessions, current)
+		}
+		current.End = t
+		current.Wins += wins
+		current.Losses += losses
+	}
+	return sessions
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory_Sessions(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	history := &History{}
+	add := func(minutes int, stats map[string]string) {
+		snapshot := &Snapshot{Time: t0.Add(time.Duration(minutes) * time.Minute), Stats: map[string]*Statistics{}}
+		for sName, statString := range stats {
+			snapshot.Stats[sName], _ = NewStatisticsFromString(statString)
+		}
+		history.Add(snapshot)
+	}
+	add(0, map[string]string{"freecell.scm": "0;0;0;0;"})
+	add(10, map[string]string{"freecell.scm": "1;1;60;60;"})
+	add(20, map[string]string{"freecell.scm": "1;1;60;60;", "spider.scm": "0;1;0;0;"})
+	add(45, map[string]string{"freecell.scm": "1;2;60;60;", "spider.scm": "0;1;0;0;"})
+	add(120, map[string]string{"freecell.scm": "3;4;50;60;", "spider.scm": "0;1;0;0;"})
+
+	sessions := history.Sessions(DefaultSessionGap)
+	assert.Equal(t, 2, len(sessions))
+	assert.Equal(t, t0.Add(10*time.Minute), sessions[0].Start)
+	assert.Equal(t, t0.Add(45*time.Minute), sessions[0].End)
+	assert.Equal(t, 1, sessions[0].Wins)
+	assert.Equal(t, 2, sessions[0].Losses)
+	assert.Equal(t, 2, sessions[1].Played())
+
+	sessions = history.Sessions(DefaultSessionGap, "spider.scm")
+	assert.Equal(t, 1, len(sessions))
+	assert.Equal(t, t0.Add(20*time.Minute), sessions[0].Start)
+
+	sessions = history.Sessions(5*time.Minute, "freecell.scm")
+	assert.Equal(t, 3, len(sessions))
+
+	// The games after a reset are counted from zero
+	add(130, map[string]string{"freecell.scm": "1;1;60;60;", "spider.scm": "0;1;0;0;"})
+	sessions = history.Sessions(DefaultSessionGap, "freecell.scm")
+	assert.Equal(t, 3, len(sessions))
+	assert.Equal(t, 3, sessions[2].Wins)
+}
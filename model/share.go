@@ -0,0 +1,163 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Share is one player's statistics in a form that can be given to other
+// players, e.g., for a leaderboard. It holds the values that Aisleriot
+// keeps, so that everyone computes the same percentages and times. It
+// can be signed with a key that the players share.
+type Share struct {
+	Format    string       `json:"format"` // Always ShareFormat
+	Version   int          `json:"version"`
+	Player    string       `json:"player"`
+	Time      time.Time    `json:"time"` // When the statistics file was written
+	Games     []*ShareGame `json:"games"`
+	Signature string       `json:"signature,omitempty"` // Hex HMAC-SHA256
+}
+
+// ShareGame is the statistics for one game in a Share
+type ShareGame struct {
+	Section string `json:"section"`
+	Wins    int    `json:"wins"`
+	Total   int    `json:"total"`
+	Best    int    `json:"best"`  // Seconds
+	Worst   int    `json:"worst"` // Seconds
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+// ShareFormat identifies a Share document
+const ShareFormat = "arstats-share"
+
+// ShareVersion is the version of the Share document written
+const ShareVersion = 1
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+var (
+	ErrUnsigned     = errors.New("not signed")
+	ErrBadSignature = errors.New("signature does not match")
+)
+
+// ---------------------------------------------------------------------
+// Constructors
+// ---------------------------------------------------------------------
+
+// NewShare returns the statistics in the snapshot for the specified
+// games, or for every game if none are specified
+func NewShare(player string, snapshot *Snapshot, sNames ...string) *Share {
+	if len(sNames) == 0 {
+		for sName := range snapshot.Stats {
+			sNames = append(sNames, sName)
+		}
+	}
+	sort.Strings(sNames)
+	share := &Share{
+		Format:  ShareFormat,
+		Version: ShareVersion,
+		Player:  player,
+		Time:    snapshot.Time,
+		Games:   []*ShareGame{},
+	}
+	for _, sName := range sNames {
+		if ps, ok := snapshot.Stats[sName]; ok {
+			share.Games = append(share.Games, &ShareGame{
+				Section: sName,
+				Wins:    ps.Wins(),
+				Total:   ps.Total(),
+				Best:    ps.Best(),
+				Worst:   ps.Worst(),
+			})
+		}
+	}
+	return share
+}
+
+// ReadShare reads a Share document. Games whose statistics are
+// impossible are rejected, as they are in a statistics file.
+func ReadShare(r io.Reader) (*Share, error) {
+	share := &Share{}
+	if err := json.NewDecoder(r).Decode(share); err != nil {
+		return nil, err
+	}
+	if share.Format != ShareFormat {
+		return nil, fmt.Errorf("not an %s document", ShareFormat)
+	}
+	if share.Version > ShareVersion {
+		return nil, fmt.Errorf("unsupported version %d", share.Version)
+	}
+	if share.Player == "" {
+		return nil, errors.New("no player name")
+	}
+	for _, game := range share.Games {
+		if _, err := NewValidStatistics(game.Wins, game.Total, game.Best, game.Worst); err != nil {
+			return nil, fmt.Errorf("%s: %w", game.Section, err)
+		}
+	}
+	return share, nil
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Stats returns the statistics for each game, by section name
+func (s *Share) Stats() map[string]*Statistics {
+	stats := map[string]*Statistics{}
+	for _, game := range s.Games {
+		stats[game.Section] = NewStatistics(game.Wins, game.Total, game.Best, game.Worst)
+	}
+	return stats
+}
+
+// Sign sets the signature for the key
+func (s *Share) Sign(key []byte) {
+	s.Signature = s.signature(key)
+}
+
+// Verify returns ErrUnsigned or ErrBadSignature unless the share was
+// signed with the key
+func (s *Share) Verify(key []byte) error {
+	if s.Signature == "" {
+		return ErrUnsigned
+	}
+	if !hmac.Equal([]byte(s.Signature), []byte(s.signature(key))) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// signature returns the HMAC of the share without its signature
+func (s *Share) signature(key []byte) string {
+	unsigned := *s
+	unsigned.Signature = ""
+	data, _ := json.Marshal(unsigned)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Write writes the share as indented JSON
+func (s *Share) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
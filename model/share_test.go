@@ -0,0 +1,69 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShare(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	snapshot := newTestSnapshot(t1, "freecell.scm", "175;209;88;406;")
+	snapshot.Stats["spider.scm"], _ = NewStatisticsFromString("45;244;479;907;")
+
+	share := NewShare("alice", snapshot)
+	assert.Equal(t, ShareFormat, share.Format)
+	assert.Equal(t, t1, share.Time)
+	assert.Equal(t, 2, len(share.Games))
+	assert.Equal(t, "freecell.scm", share.Games[0].Section)
+	assert.Equal(t, "175;209;88;406;", share.Stats()["freecell.scm"].String())
+
+	share = NewShare("alice", snapshot, "spider.scm", "klondike.scm")
+	assert.Equal(t, 1, len(share.Games))
+	assert.Equal(t, "spider.scm", share.Games[0].Section)
+}
+
+func TestShare_WriteAndRead(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+	share := NewShare("alice", newTestSnapshot(t1, "freecell.scm", "175;209;88;406;"))
+	key := []byte("secret")
+	share.Sign(key)
+
+	var buf bytes.Buffer
+	assert.Nil(t, share.Write(&buf))
+	have, err := ReadShare(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, share, have)
+	assert.Nil(t, have.Verify(key))
+	assert.Equal(t, ErrBadSignature, have.Verify([]byte("other")))
+
+	have.Games[0].Wins++
+	assert.Equal(t, ErrBadSignature, have.Verify(key))
+	have.Signature = ""
+	assert.Equal(t, ErrUnsigned, have.Verify(key))
+}
+
+func TestReadShare_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", "[freecell.scm]"},
+		{"not a share", `{"recent": [], "games": []}`},
+		{"newer version", `{"format": "arstats-share", "version": 99, "player": "alice"}`},
+		{"no player", `{"format": "arstats-share", "version": 1}`},
+		{"impossible statistics", `{"format": "arstats-share", "version": 1, "player": "alice",
+			"games": [{"section": "freecell.scm", "wins": 5, "total": 4, "best": 60, "worst": 90}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadShare(strings.NewReader(tt.data))
+			assert.NotNil(t, err)
+		})
+	}
+	_, err := ReadShare(strings.NewReader(tests[len(tests)-1].data))
+	assert.ErrorIs(t, err, ErrImpossible)
+}
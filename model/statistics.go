@@ -1,10 +1,12 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ---------------------------------------------------------------------
@@ -22,6 +24,28 @@ type Statistics struct {
 	pct     int // Multiplied by 100 and rounded to nearest integer
 }
 
+// Delta is the change from one Statistics to another. The changes in
+// the times are zero unless both have times.
+type Delta struct {
+	From       *Statistics // Earlier statistics
+	To         *Statistics // Later statistics
+	Wins       int
+	Losses     int
+	Total      int
+	Best       int     // Negative if the best time improved
+	Average    int     // Change in the average of best and worst
+	Worst      int     // Positive if the worst time got longer
+	Percentage float64 // Change in ExactPercentage
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// ErrImpossible is wrapped by the errors for statistics that Aisleriot
+// could not have written, such as more wins than games
+var ErrImpossible = errors.New("impossible statistics")
+
 // ---------------------------------------------------------------------
 // Constructors
 // ---------------------------------------------------------------------
@@ -36,6 +60,10 @@ type Statistics struct {
 //   - losses
 //   - average
 //   - percentage of wins
+//
+// The values are not checked, so that totals and other derived
+// statistics can be made. Use NewValidStatistics for values read from
+// outside the program.
 func NewStatistics(wins, total, best, worst int) *Statistics {
 	stats := new(Statistics)
 	stats.wins = wins
@@ -50,9 +78,35 @@ func NewStatistics(wins, total, best, worst int) *Statistics {
 	return stats
 }
 
+// NewValidStatistics is like NewStatistics, but rejects values that
+// Aisleriot could not have written with the errors from Validate.
+func NewValidStatistics(wins, total, best, worst int) (*Statistics, error) {
+	ps := NewStatistics(wins, total, best, worst)
+	if err := ps.Validate(); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
 // Creates a new Statistics object from the string representation
-// that is in the configuration file, e.g., "99;150;144;208;"
+// that is in the configuration file, e.g., "99;150;144;208;". Values
+// that Aisleriot could not have written are rejected with the errors
+// from Validate.
 func NewStatisticsFromString(statString string) (*Statistics, error) {
+	ps, err := ParseStatistics(statString)
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.Validate(); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// ParseStatistics is like NewStatisticsFromString, but only checks that
+// there are four numbers, so that impossible values can be inspected
+// or repaired
+func ParseStatistics(statString string) (*Statistics, error) {
 	statString = strings.TrimSuffix(statString, ";")
 	tokens := strings.Split(statString, ";")
 	if len(tokens) != 4 {
@@ -81,6 +135,51 @@ func NewStatisticsFromString(statString string) (*Statistics, error) {
 	return NewStatistics(wins, total, best, worst), nil
 }
 
+// Total adds up the statistics for several games. The best time is the
+// shortest best time of any game that has been won, and the worst time
+// the longest worst time.
+func Total(stats ...*Statistics) *Statistics {
+	wins, total, best, worst := 0, 0, 0, 0
+	for _, ps := range stats {
+		wins += ps.wins
+		total += ps.total
+		if ps.HasTimes() {
+			if best == 0 || ps.best < best {
+				best = ps.best
+			}
+			if ps.worst > worst {
+				worst = ps.worst
+			}
+		}
+	}
+	return NewStatistics(wins, total, best, worst)
+}
+
+// ParseSeconds converts a time written as seconds, m:ss, h:mm:ss,
+// ISO-8601 (PT1H2M7S), or compact (1h2m7s) into a number of seconds
+func ParseSeconds(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "PT") {
+		value = strings.ToLower(strings.TrimPrefix(value, "PT"))
+	}
+	if strings.ContainsAny(value, "hms") {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid time %q", value)
+		}
+		return int(d.Round(time.Second) / time.Second), nil
+	}
+	seconds := 0
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time %q", value)
+		}
+		seconds = 60*seconds + n
+	}
+	return seconds, nil
+}
+
 // ---------------------------------------------------------------------
 // Methods
 // ---------------------------------------------------------------------
@@ -105,6 +204,13 @@ func (ps *Statistics) Best() int {
 	return ps.best
 }
 
+// HasTimes returns true if at least one game has been won, so that
+// Best(), Average(), and Worst() are real times. When there are no
+// wins, AisleRiot records zero for the times.
+func (ps *Statistics) HasTimes() bool {
+	return ps.wins > 0
+}
+
 // Average returns the integer average of Best() and Worst()
 func (ps *Statistics) Average() int {
 	return ps.average
@@ -121,6 +227,93 @@ func (ps *Statistics) Percentage() int {
 	return ps.pct
 }
 
+// String returns the statistics in the form used in the configuration
+// file, e.g., "99;150;144;208;"
+func (ps *Statistics) String() string {
+	return fmt.Sprintf("%d;%d;%d;%d;", ps.wins, ps.total, ps.best, ps.worst)
+}
+
+// Validate returns nil if Aisleriot could have written the statistics.
+// Otherwise it returns an error for each inconsistency, joined with
+// errors.Join, and each wrapping ErrImpossible.
+func (ps *Statistics) Validate() error {
+	errs := []error{}
+	problem := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrImpossible}, args...)...))
+	}
+	for _, value := range []struct {
+		name string
+		n    int
+	}{
+		{"wins", ps.wins},
+		{"total", ps.total},
+		{"best time", ps.best},
+		{"worst time", ps.worst},
+	} {
+		if value.n < 0 {
+			problem("%s (%d) is negative", value.name, value.n)
+		}
+	}
+	if ps.wins > ps.total {
+		problem("wins (%d) greater than total (%d)", ps.wins, ps.total)
+	}
+	if ps.best > ps.worst {
+		problem("best time (%d) greater than worst time (%d)", ps.best, ps.worst)
+	}
+	if ps.wins == 0 && (ps.best != 0 || ps.worst != 0) {
+		problem("best time (%d) and worst time (%d) with no wins", ps.best, ps.worst)
+	}
+	return errors.Join(errs...)
+}
+
+// ExactPercentage returns the winning fraction multiplied by 100,
+// without rounding
+func (ps *Statistics) ExactPercentage() float64 {
+	if ps.total == 0 {
+		return 0
+	}
+	return 100.0 * float64(ps.wins) / float64(ps.total)
+}
+
+// Compare returns the change from these statistics to other
+func (ps *Statistics) Compare(other *Statistics) Delta {
+	d := Delta{
+		From:       ps,
+		To:         other,
+		Wins:       other.wins - ps.wins,
+		Losses:     other.Losses() - ps.Losses(),
+		Total:      other.total - ps.total,
+		Percentage: other.ExactPercentage() - ps.ExactPercentage(),
+	}
+	if ps.HasTimes() && other.HasTimes() {
+		d.Best = other.best - ps.best
+		d.Average = other.average - ps.average
+		d.Worst = other.worst - ps.worst
+	}
+	return d
+}
+
+// GamesSince returns the number of games won and lost since the earlier
+// statistics prev. If the games won, lost, or played went down, the
+// statistics were reset, and the games are counted from zero.
+func (ps *Statistics) GamesSince(prev *Statistics) (wins, losses int) {
+	if prev.Compare(ps).Decreased() {
+		return ps.Wins(), ps.Losses()
+	}
+	return ps.Wins() - prev.Wins(), ps.Losses() - prev.Losses()
+}
+
+// Changed returns true if any of the statistics are different
+func (d Delta) Changed() bool {
+	return *d.From != *d.To
+}
+
+// Decreased returns true if the games won, lost, or played went down,
+// which happens only when statistics are reset or lost
+func (d Delta) Decreased() bool {
+	return d.Wins < 0 || d.Losses < 0 || d.Total < 0
+}
+
 // WinsToNextHigher returns the number of wins that will make the
 // winning percentage one integer higher.
 func (ps *Statistics) WinsToNextHigher() int {
@@ -162,3 +355,24 @@ func (ps *Statistics) LossesToNextLower() int {
 		}
 	}
 }
+
+// WinsToReach returns the number of consecutive wins needed to raise
+// the winning percentage to at least pct, or -1 if that is impossible
+// (pct over 100, or exactly 100 with losses recorded).
+func (ps *Statistics) WinsToReach(pct int) int {
+	if ps.Percentage() >= pct {
+		return 0
+	}
+	if pct > 100 || (pct == 100 && ps.Losses() > 0) {
+		return -1
+	}
+	wins, losses := ps.Wins(), ps.Losses()
+	for {
+		wins++
+		total := wins + losses
+		nextPct := int(math.Round(100 * float64(wins) / float64(total)))
+		if nextPct >= pct {
+			return wins - ps.Wins()
+		}
+	}
+}
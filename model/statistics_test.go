@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,6 +30,17 @@ func TestAccessors(t *testing.T) {
 	assert.Equal(t, -1, ps.LossesToNextLower())
 }
 
+func TestNewValidStatistics(t *testing.T) {
+	ps, err := NewValidStatistics(45, 241, 479, 907)
+	assert.Nil(t, err)
+	assert.Equal(t, 196, ps.Losses())
+
+	ps, err = NewValidStatistics(5, 4, 60, 90)
+	assert.Nil(t, ps)
+	assert.ErrorIs(t, err, ErrImpossible)
+	assert.ErrorContains(t, err, "wins (5) greater than total (4)")
+}
+
 func TestNewStatisticsFromString(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -46,6 +58,10 @@ func TestNewStatisticsFromString(t *testing.T) {
 		{"bad total", "1;bogus;1;1;", nil, true, "total"},
 		{"bad best", "1;1;bogus;1;", nil, true, "best"},
 		{"bad worst", "1;1;1;bogus;", nil, true, "worst"},
+		{"wins over total", "5;4;60;90;", nil, true, "wins (5) greater than total (4)"},
+		{"best over worst", "5;10;90;60;", nil, true, "best time (90) greater than worst time (60)"},
+		{"negative", "-1;10;60;90;", nil, true, "wins (-1) is negative"},
+		{"times with no wins", "0;10;60;90;", nil, true, "with no wins"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -86,3 +102,220 @@ func TestStatistics_WinsToNextHigher(t *testing.T) {
 		})
 	}
 }
+
+func TestStatistics_HasTimes(t *testing.T) {
+	tests := []struct {
+		name       string
+		statString string
+		want       bool
+	}{
+		{"no games", "0;0;0;0;", false},
+		{"1 loss", "0;1;0;0;", false},
+		{"1 win", "1;4;511;511;", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, err := NewStatisticsFromString(tt.statString)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, ps.HasTimes())
+		})
+	}
+}
+
+func TestStatistics_WinsToReach(t *testing.T) {
+	tests := []struct {
+		name       string
+		statString string
+		pct        int
+		want       int
+	}{
+		{"already there", "45;241;479;907;", 10, 0},
+		{"next percent", "45;241;479;907;", 20, 3},
+		{"no games", "0;0;0;0;", 50, 1},
+		{"half", "1;3;0;0;", 50, 1},
+		{"impossible 100", "1;2;0;0;", 100, -1},
+		{"impossible over 100", "1;1;0;0;", 101, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, err := NewStatisticsFromString(tt.statString)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, ps.WinsToReach(tt.pct))
+		})
+	}
+}
+
+func TestStatistics_ExactPercentage(t *testing.T) {
+	tests := []struct {
+		name       string
+		statString string
+		want       float64
+	}{
+		{"no games", "0;0;0;0;", 0},
+		{"third", "1;3;0;0;", 100.0 / 3},
+		{"all", "2;2;0;0;", 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, err := NewStatisticsFromString(tt.statString)
+			assert.Nil(t, err)
+			assert.InDelta(t, tt.want, ps.ExactPercentage(), 1e-9)
+		})
+	}
+}
+
+func TestStatistics_Compare(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		want      Delta
+		changed   bool
+		decreased bool
+	}{
+		{"same", "5;10;60;90;", "5;10;60;90;", Delta{}, false, false},
+		{"win with new best", "5;10;60;90;", "6;11;50;90;",
+			Delta{Wins: 1, Total: 1, Best: -10, Average: -5, Percentage: 600.0/11 - 50}, true, false},
+		{"loss", "5;10;60;90;", "5;11;60;90;",
+			Delta{Losses: 1, Total: 1, Percentage: 500.0/11 - 50}, true, false},
+		{"first win", "0;4;0;0;", "1;5;80;80;",
+			Delta{Wins: 1, Total: 1, Percentage: 20}, true, false},
+		{"reset", "5;10;60;90;", "0;0;0;0;",
+			Delta{Wins: -5, Losses: -5, Total: -10, Percentage: -50}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, _ := NewStatisticsFromString(tt.from)
+			to, _ := NewStatisticsFromString(tt.to)
+			d := from.Compare(to)
+			assert.Same(t, from, d.From)
+			assert.Same(t, to, d.To)
+			assert.InDelta(t, tt.want.Percentage, d.Percentage, 1e-9)
+			d.From, d.To, d.Percentage = nil, nil, 0
+			tt.want.Percentage = 0
+			assert.Equal(t, tt.want, d)
+			d = from.Compare(to)
+			assert.Equal(t, tt.changed, d.Changed())
+			assert.Equal(t, tt.decreased, d.Decreased())
+		})
+	}
+}
+
+func TestTotal(t *testing.T) {
+	stats := func(statStrings ...string) []*Statistics {
+		list := []*Statistics{}
+		for _, statString := range statStrings {
+			ps, _ := NewStatisticsFromString(statString)
+			list = append(list, ps)
+		}
+		return list
+	}
+	tests := []struct {
+		name  string
+		stats []*Statistics
+		want  string
+	}{
+		{"none", stats(), "0;0;0;0;"},
+		{"one", stats("5;10;60;90;"), "5;10;60;90;"},
+		{"several", stats("5;10;60;90;", "0;4;0;0;", "2;3;45;80;"), "7;17;45;90;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Total(tt.stats...).String())
+		})
+	}
+}
+
+func TestStatistics_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		problems []string
+	}{
+		{"valid", "45;241;479;907;", nil},
+		{"no games", "0;0;0;0;", nil},
+		{"wins over total", "5;4;60;90;", []string{"wins (5) greater than total (4)"}},
+		{"negative total", "0;-3;0;0;", []string{
+			"total (-3) is negative",
+			"wins (0) greater than total (-3)",
+		}},
+		{"several", "-1;-2;90;-60;", []string{
+			"wins (-1) is negative",
+			"total (-2) is negative",
+			"worst time (-60) is negative",
+			"wins (-1) greater than total (-2)",
+			"best time (90) greater than worst time (-60)",
+		}},
+		{"no wins", "0;5;30;0;", []string{
+			"best time (30) greater than worst time (0)",
+			"best time (30) and worst time (0) with no wins",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, err := ParseStatistics(tt.line)
+			assert.Nil(t, err)
+			err = ps.Validate()
+			if tt.problems == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrImpossible)
+			lines := strings.Split(err.Error(), "\n")
+			assert.Equal(t, len(tt.problems), len(lines))
+			for i, problem := range tt.problems {
+				assert.Equal(t, ErrImpossible.Error()+": "+problem, lines[i])
+			}
+		})
+	}
+}
+
+func TestParseSeconds(t *testing.T) {
+	tests := []struct {
+		value         string
+		expected      int
+		expectedError bool
+	}{
+		{"07:59", 479, false},
+		{"2:00:07", 7207, false},
+		{"PT0S", 0, false},
+		{"PT1H2M", 3720, false},
+		{"2h0m7s", 7207, false},
+		{"7207", 7207, false},
+		{"bogus", 0, true},
+		{"1:-2", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			seconds, err := ParseSeconds(tt.value)
+			if tt.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, seconds)
+			}
+		})
+	}
+}
+
+func TestStatistics_GamesSince(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next string
+		wins       int
+		losses     int
+	}{
+		{"no change", "3;5;60;60;", "3;5;60;60;", 0, 0},
+		{"more games", "3;5;60;60;", "5;8;60;60;", 2, 1},
+		{"reset", "3;5;60;60;", "1;1;60;60;", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, _ := NewStatisticsFromString(tt.prev)
+			next, _ := NewStatisticsFromString(tt.next)
+			wins, losses := next.GamesSince(prev)
+			assert.Equal(t, tt.wins, wins)
+			assert.Equal(t, tt.losses, losses)
+		})
+	}
+}
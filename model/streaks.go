@@ -0,0 +1,114 @@
+package model
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Streak is a number of wins or losses in a row. When several games
+// were played between two snapshots, their order is not known, so the
+// length may only be a lower bound.
+type Streak struct {
+	Length  int  // Number of games
+	AtLeast bool // True if Length is a lower bound
+}
+
+// Streaks are the current and longest streaks for one game
+type Streaks struct {
+	Current       Streak // Wins or losses in a row up to the latest game
+	CurrentIsWin  bool   // True if Current is a winning streak
+	CurrentKnown  bool   // False if the result of the latest game is not known
+	LongestWins   Streak
+	LongestLosses Streak
+}
+
+// ---------------------------------------------------------------------
+// Constructor
+// ---------------------------------------------------------------------
+
+// NewStreaks computes the streaks for one game from its statistics in
+// successive snapshots. Nothing is known about the games played before
+// the first snapshot, so a streak that began before it is a lower
+// bound. If the number of games goes down, the statistics were reset,
+// and the current streak starts again.
+func NewStreaks(samples []Sample) *Streaks {
+	st := &Streaks{}
+	if len(samples) == 0 {
+		return st
+	}
+
+	// A game with no games played at the first snapshot has no earlier
+	// streak to extend.
+	st.CurrentKnown = samples[0].Stats.Total() == 0
+	for i := 1; i < len(samples); i++ {
+		prev, next := samples[i-1].Stats, samples[i].Stats
+		wins := next.Wins() - prev.Wins()
+		losses := next.Losses() - prev.Losses()
+		switch {
+		case wins < 0 || losses < 0:
+			// Reset
+			st.Current = Streak{}
+			st.CurrentKnown = next.Total() == 0
+			continue
+		case wins == 0 && losses == 0:
+			continue
+		}
+		switch {
+		case losses == 0:
+			st.extend(true, wins)
+		case wins == 0:
+			st.extend(false, losses)
+		default:
+			// The order of the games is not known, but the wins are
+			// divided by the losses into at most losses+1 runs, so one
+			// run has at least wins/(losses+1) of them, and the same
+			// for the losses.
+			st.longest(true, Streak{ceilDiv(wins, losses+1), true})
+			st.longest(false, Streak{ceilDiv(losses, wins+1), true})
+			st.Current = Streak{}
+			st.CurrentKnown = false
+		}
+	}
+	return st
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// extend adds n games with the same result to the current streak
+func (st *Streaks) extend(win bool, n int) {
+	switch {
+	case !st.CurrentKnown:
+		st.Current = Streak{n, true}
+	case st.Current.Length == 0 || st.CurrentIsWin != win:
+		st.Current = Streak{n, false}
+	default:
+		st.Current.Length += n
+	}
+	st.CurrentIsWin = win
+	st.CurrentKnown = true
+	st.longest(win, st.Current)
+}
+
+// longest replaces the longest streak if the candidate is longer. If
+// either is a lower bound, so is the result.
+func (st *Streaks) longest(win bool, candidate Streak) {
+	p := &st.LongestLosses
+	if win {
+		p = &st.LongestWins
+	}
+	atLeast := p.AtLeast || candidate.AtLeast
+	if candidate.Length > p.Length {
+		*p = candidate
+	}
+	p.AtLeast = atLeast
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// ceilDiv returns a/b rounded up, for positive a and b
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
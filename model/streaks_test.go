@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStreaks(t *testing.T) {
+	tests := []struct {
+		name          string
+		statStrings   []string
+		current       Streak
+		currentIsWin  bool
+		currentKnown  bool
+		longestWins   Streak
+		longestLosses Streak
+	}{
+		{"no history", []string{}, Streak{}, false, false, Streak{}, Streak{}},
+		{"from the first game", []string{"0;0;0;0;", "1;1;0;0;", "2;2;0;0;", "2;3;0;0;"},
+			Streak{1, false}, false, true, Streak{2, false}, Streak{1, false}},
+		{"before first snapshot", []string{"5;9;0;0;", "6;10;0;0;", "7;11;0;0;"},
+			Streak{2, true}, true, true, Streak{2, true}, Streak{}},
+		{"lost then won", []string{"5;9;0;0;", "5;10;0;0;", "6;11;0;0;", "7;12;0;0;"},
+			Streak{2, false}, true, true, Streak{2, false}, Streak{1, true}},
+		{"several games", []string{"0;0;0;0;", "5;6;0;0;"},
+			Streak{}, false, false, Streak{3, true}, Streak{1, true}},
+		{"after several games", []string{"0;0;0;0;", "5;6;0;0;", "6;7;0;0;"},
+			Streak{1, true}, true, true, Streak{3, true}, Streak{1, true}},
+		{"reset", []string{"0;0;0;0;", "3;3;0;0;", "0;0;0;0;", "0;1;0;0;"},
+			Streak{1, false}, false, true, Streak{3, false}, Streak{1, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := []Sample{}
+			t0 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+			for i, statString := range tt.statStrings {
+				ps, err := NewStatisticsFromString(statString)
+				assert.Nil(t, err)
+				samples = append(samples, Sample{t0.Add(time.Duration(i) * time.Hour), ps})
+			}
+			st := NewStreaks(samples)
+			assert.Equal(t, tt.current, st.Current, "current")
+			assert.Equal(t, tt.currentIsWin, st.CurrentIsWin, "current is win")
+			assert.Equal(t, tt.currentKnown, st.CurrentKnown, "current known")
+			assert.Equal(t, tt.longestWins, st.LongestWins, "longest wins")
+			assert.Equal(t, tt.longestLosses, st.LongestLosses, "longest losses")
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// User is a local account and its statistics file
+type User struct {
+	Name     string
+	HomeDir  string
+	Filename string
+	Err      error // Why the statistics file cannot be read, or nil
+}
+
+// Resolver finds the statistics files of the local users.
+// DefaultResolver uses HomeFileName, which finds the file the same way
+// as DefaultFileName does for the current user.
+type Resolver struct {
+	HomePattern string                      // Glob matching home directories
+	FileName    func(homeDir string) string // Statistics file in a home directory
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// DefaultResolver looks for users in /home
+var DefaultResolver = &Resolver{
+	HomePattern: "/home/*",
+	FileName:    HomeFileName,
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Users returns the users whose home directories match HomePattern and
+// have a statistics file, in order of name. Users whose file exists but
+// cannot be read are included, with the reason in Err.
+func (r *Resolver) Users() ([]*User, error) {
+	homeDirs, err := filepath.Glob(r.HomePattern)
+	if err != nil {
+		return nil, err
+	}
+	users := []*User{}
+	for _, homeDir := range homeDirs {
+		u := r.newUser(filepath.Base(homeDir), homeDir)
+		if errors.Is(u.Err, fs.ErrNotExist) {
+			continue
+		}
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// Lookup returns the user with the specified name, whose home directory
+// matches HomePattern or is known to the system. The user is returned
+// even if the statistics file cannot be read, with the reason in Err.
+func (r *Resolver) Lookup(name string) (*User, error) {
+	homeDirs, err := filepath.Glob(r.HomePattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, homeDir := range homeDirs {
+		if filepath.Base(homeDir) == name {
+			return r.newUser(name, homeDir), nil
+		}
+	}
+	if pu, err := user.Lookup(name); err == nil && pu.HomeDir != "" {
+		return r.newUser(name, pu.HomeDir), nil
+	}
+	return nil, fmt.Errorf("unknown user %q", name)
+}
+
+// newUser returns a user, checking that the statistics file can be read
+func (r *Resolver) newUser(name, homeDir string) *User {
+	u := &User{Name: name, HomeDir: homeDir, Filename: r.FileName(homeDir)}
+	fp, err := os.Open(u.Filename)
+	if err == nil {
+		_, err = fp.Read(make([]byte, 1))
+		fp.Close()
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	u.Err = err
+	return u
+}
+
+// DataProvider reads the user's statistics file
+func (u *User) DataProvider() (*DataProvider, error) {
+	if u.Err != nil {
+		return nil, u.Err
+	}
+	return NewDataProvider(u.Filename)
+}
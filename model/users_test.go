@@ -0,0 +1,82 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestHomes creates home directories for alice, with a statistics
+// file, bob, with none, and carol, whose file cannot be read
+func newTestHomes(t *testing.T) *Resolver {
+	dir := t.TempDir()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(dir, name), 0o755))
+	}
+	alice := HomeFileName(filepath.Join(dir, "alice"))
+	assert.Nil(t, os.MkdirAll(filepath.Dir(alice), 0o755))
+	assert.Nil(t, os.WriteFile(alice, data, 0o644))
+	assert.Nil(t, os.MkdirAll(HomeFileName(filepath.Join(dir, "carol")), 0o755))
+	return &Resolver{HomePattern: filepath.Join(dir, "*"), FileName: HomeFileName}
+}
+
+func TestResolver_Users(t *testing.T) {
+	r := newTestHomes(t)
+	users, err := r.Users()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(users))
+
+	assert.Equal(t, "alice", users[0].Name)
+	assert.Nil(t, users[0].Err)
+	pdp, err := users[0].DataProvider()
+	assert.Nil(t, err)
+	assert.Equal(t, "spider", pdp.MostRecentGame())
+
+	assert.Equal(t, "carol", users[1].Name)
+	assert.NotNil(t, users[1].Err)
+	_, err = users[1].DataProvider()
+	assert.NotNil(t, err)
+}
+
+func TestResolver_Lookup(t *testing.T) {
+	r := newTestHomes(t)
+	tests := []struct {
+		name    string
+		wantErr bool
+		fileErr bool
+	}{
+		{"alice", false, false},
+		{"bob", false, true},
+		{"no-such-user", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := r.Lookup(tt.name)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.name, u.Name)
+			assert.Equal(t, HomeFileName(u.HomeDir), u.Filename)
+			assert.Equal(t, tt.fileErr, u.Err != nil)
+		})
+	}
+}
+
+func TestHomeFileName(t *testing.T) {
+	assert.Equal(t, "/home/alice/.config/gnome-games/aisleriot", HomeFileName("/home/alice"))
+	assert.Equal(t, "/home/alice/.local/share/arstats/history", HomeHistoryFileName("/home/alice"))
+	assert.Equal(t, "/home/alice/.local/share/arstats/achievements", HomeAchievementsFileName("/home/alice"))
+
+	// The current user's files are found the same way
+	t.Setenv("HOME", "/home/alice")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	assert.Equal(t, DefaultFileName(), DefaultResolver.FileName("/home/alice"))
+	assert.Equal(t, DefaultHistoryFileName(), HomeHistoryFileName("/home/alice"))
+}
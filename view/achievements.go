@@ -0,0 +1,51 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintAchievements writes every achievement, marking the unlocked ones
+// with the date they were unlocked. Unlocked achievements are listed
+// first, oldest first.
+func PrintAchievements(w io.Writer, rules []*model.Rule, pa *model.Achievements) {
+	unlocked, locked := []*model.Rule{}, []*model.Rule{}
+	for _, rule := range rules {
+		if _, ok := pa.Unlocked[rule.Name]; ok {
+			unlocked = append(unlocked, rule)
+		} else {
+			locked = append(locked, rule)
+		}
+	}
+	sortRules(unlocked, pa)
+
+	nameWidth := 0
+	for _, rule := range rules {
+		if n := DisplayWidth(T(rule.Name)); n > nameWidth {
+			nameWidth = n
+		}
+	}
+	for _, rule := range append(unlocked, locked...) {
+		mark, date := " ", ""
+		if t, ok := pa.Unlocked[rule.Name]; ok {
+			mark = colorize("✔", ansiGreen)
+			date = t.Local().Format("2006-01-02")
+		}
+		line := fmt.Sprintf("%s %-10s %s  %s", mark, date, PadRight(T(rule.Name), nameWidth), T(rule.Description))
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(w, "\n"+T("%s of %s unlocked")+"\n",
+		CurrentLocale.FormatInt(len(unlocked)), CurrentLocale.FormatInt(len(rules)))
+}
+
+// sortRules sorts unlocked rules by the time they were unlocked
+func sortRules(rules []*model.Rule, pa *model.Achievements) {
+	for i := 1; i < len(rules); i++ {
+		for j := i; j > 0 && pa.Unlocked[rules[j].Name].Before(pa.Unlocked[rules[j-1].Name]); j-- {
+			rules[j], rules[j-1] = rules[j-1], rules[j]
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintAchievements(t *testing.T) {
+	rules := []*model.Rule{
+		{Name: "First win", Description: "Win a game"},
+		{Name: "Explorer", Description: "Play 20 different games"},
+		{Name: "Quick"},
+	}
+	pa := &model.Achievements{Unlocked: map[string]time.Time{
+		"Quick":     time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local),
+		"First win": time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local),
+	}}
+	var buf bytes.Buffer
+	PrintAchievements(&buf, rules, pa)
+	assert.Equal(t, []string{
+		"✔ 2024-03-01 First win  Win a game",
+		"✔ 2024-03-02 Quick",
+		"             Explorer   Play 20 different games",
+		"",
+		"2 of 3 unlocked",
+	}, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"))
+}
@@ -0,0 +1,292 @@
+package view
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Calendar is a heat map of the games played on each day, with one
+// column per week and one row per day of the week
+type Calendar struct {
+	First  time.Time               // First day shown
+	Last   time.Time               // Last day shown
+	Metric string                  // "games", "wins", or "rate"
+	days   map[string]*calendarDay // Date to games played that day
+	max    int                     // Largest value of the metric
+}
+
+// calendarDay is the games played on one day
+type calendarDay struct {
+	wins   int
+	losses int
+}
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+// SVG layout, in pixels
+const (
+	svgCell   = 11 // Size of a day
+	svgStep   = 13 // Distance between days
+	svgLeft   = 32 // Room for the day names
+	svgTop    = 20 // Room for the month names
+	svgBottom = 24 // Room for the legend
+)
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// calendarBlocks are the terminal cells for each level
+var calendarBlocks = []string{"·", "░", "▒", "▓", "█"}
+
+// calendarColors are the SVG fills for each level
+var calendarColors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+// CalendarMetrics are the values that a calendar can show
+var CalendarMetrics = []string{"games", "wins", "rate"}
+
+// monthNames are the English abbreviations, which are translated by T
+var monthNames = []string{
+	"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+	"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+}
+
+// dayNames are shown for every other row, as on GitHub
+var dayNames = []string{"Mon", "", "Wed", "", "Fri", "", ""}
+
+// ---------------------------------------------------------------------
+// Constructor
+// ---------------------------------------------------------------------
+
+// NewCalendar counts the games in each session on the day the session
+// started, for the days from first to last.
+func NewCalendar(sessions []*model.Session, first, last time.Time, metric string) (*Calendar, error) {
+	switch metric {
+	case "games", "wins", "rate":
+	default:
+		return nil, fmt.Errorf("invalid metric %q", metric)
+	}
+	c := &Calendar{
+		First:  startOfDay(first),
+		Last:   startOfDay(last),
+		Metric: metric,
+		days:   map[string]*calendarDay{},
+	}
+	for _, session := range sessions {
+		day := startOfDay(session.Start.In(first.Location()))
+		if day.Before(c.First) || day.After(c.Last) {
+			continue
+		}
+		key := day.Format("2006-01-02")
+		cd, ok := c.days[key]
+		if !ok {
+			cd = &calendarDay{}
+			c.days[key] = cd
+		}
+		cd.wins += session.Wins
+		cd.losses += session.Losses
+	}
+	for _, cd := range c.days {
+		if v := c.value(cd); v > c.max {
+			c.max = v
+		}
+	}
+	return c, nil
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// WriteText writes the calendar with one character per day, followed
+// by a legend and totals
+func (c *Calendar) WriteText(w io.Writer) {
+	weeks := c.weeks()
+
+	// Month names above the first week of each month
+	line := []rune(strings.Repeat(" ", 4+weeks))
+	next := 0
+	for week := 0; week < weeks; week++ {
+		if day, ok := c.monthStart(week); ok {
+			name := []rune(T(monthNames[day.Month()-1]))
+			col := 4 + week
+			if col >= next && col+len(name) <= len(line) {
+				copy(line[col:], name)
+				next = col + len(name) + 1
+			}
+		}
+	}
+	fmt.Fprintln(w, strings.TrimRight(string(line), " "))
+
+	// One row for each day of the week
+	for row := 0; row < 7; row++ {
+		var sb strings.Builder
+		sb.WriteString(PadRight(Truncate(T(dayNames[row]), 3), 3) + " ")
+		for week := 0; week < weeks; week++ {
+			day := c.day(week, row)
+			if day.Before(c.First) || day.After(c.Last) {
+				sb.WriteString(" ")
+				continue
+			}
+			level := c.level(day)
+			cell := calendarBlocks[level]
+			if level > 0 {
+				cell = colorize(cell, ansiGreen)
+			}
+			sb.WriteString(cell)
+		}
+		fmt.Fprintln(w, strings.TrimRight(sb.String(), " "))
+	}
+
+	// Legend and totals
+	fmt.Fprintf(w, "\n    %s %s %s\n", T("Less"), strings.Join(calendarBlocks, ""), T("More"))
+	wins, losses, days := c.totals()
+	fmt.Fprintf(w, T("%s games played on %s days, %s won")+"\n",
+		CurrentLocale.FormatInt(wins+losses), CurrentLocale.FormatInt(days), CurrentLocale.FormatInt(wins))
+}
+
+// WriteSVG writes the calendar as an SVG image. Each day has a tooltip
+// with its date and games.
+func (c *Calendar) WriteSVG(w io.Writer) {
+	weeks := c.weeks()
+	width := svgLeft + weeks*svgStep
+	height := svgTop + 7*svgStep + svgBottom
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="9">`+"\n",
+		width, height, width, height)
+	for week := 0; week < weeks; week++ {
+		if day, ok := c.monthStart(week); ok {
+			fmt.Fprintf(w, `  <text x="%d" y="%d">%s</text>`+"\n",
+				svgLeft+week*svgStep, svgTop-6, html.EscapeString(T(monthNames[day.Month()-1])))
+		}
+	}
+	for row, name := range dayNames {
+		if name != "" {
+			fmt.Fprintf(w, `  <text x="0" y="%d">%s</text>`+"\n",
+				svgTop+row*svgStep+svgCell-2, html.EscapeString(T(name)))
+		}
+	}
+	for week := 0; week < weeks; week++ {
+		for row := 0; row < 7; row++ {
+			day := c.day(week, row)
+			if day.Before(c.First) || day.After(c.Last) {
+				continue
+			}
+			cd := c.days[day.Format("2006-01-02")]
+			if cd == nil {
+				cd = &calendarDay{}
+			}
+			title := fmt.Sprintf(T("%s: %s played, %s won"), day.Format("2006-01-02"),
+				CurrentLocale.FormatInt(cd.wins+cd.losses), CurrentLocale.FormatInt(cd.wins))
+			fmt.Fprintf(w, `  <rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s</title></rect>`+"\n",
+				svgLeft+week*svgStep, svgTop+row*svgStep, svgCell, svgCell,
+				calendarColors[c.level(day)], html.EscapeString(title))
+		}
+	}
+	y := svgTop + 7*svgStep + 8
+	fmt.Fprintf(w, `  <text x="%d" y="%d">%s</text>`+"\n", svgLeft, y+svgCell-2, html.EscapeString(T("Less")))
+	x := svgLeft + 30
+	for _, color := range calendarColors {
+		fmt.Fprintf(w, `  <rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`+"\n", x, y, svgCell, svgCell, color)
+		x += svgStep
+	}
+	fmt.Fprintf(w, `  <text x="%d" y="%d">%s</text>`+"\n", x+2, y+svgCell-2, html.EscapeString(T("More")))
+	fmt.Fprintln(w, "</svg>")
+}
+
+// weeks returns the number of columns
+func (c *Calendar) weeks() int {
+	days := int(c.Last.Sub(c.gridStart()).Hours()/24+0.5) + 1
+	return (days + 6) / 7
+}
+
+// gridStart returns the Monday on or before the first day
+func (c *Calendar) gridStart() time.Time {
+	return model.PeriodWeek.Start(c.First)
+}
+
+// day returns the date in a column and row
+func (c *Calendar) day(week, row int) time.Time {
+	return c.gridStart().AddDate(0, 0, 7*week+row)
+}
+
+// monthStart returns the first day of a month that is in the column,
+// or the first day shown if there is room for its month name before
+// the next month
+func (c *Calendar) monthStart(week int) (time.Time, bool) {
+	for row := 0; row < 7; row++ {
+		day := c.day(week, row)
+		if day.Before(c.First) || day.After(c.Last) {
+			continue
+		}
+		if day.Day() == 1 || (day.Equal(c.First) && day.Day() <= 14) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// value returns the metric for one day
+func (c *Calendar) value(cd *calendarDay) int {
+	switch c.Metric {
+	case "wins":
+		return cd.wins
+	case "rate":
+		return model.NewStatistics(cd.wins, cd.wins+cd.losses, 0, 0).Percentage()
+	default:
+		return cd.wins + cd.losses
+	}
+}
+
+// level returns the shade for a day, from 0 for no games to 4
+func (c *Calendar) level(day time.Time) int {
+	cd, ok := c.days[day.Format("2006-01-02")]
+	if !ok || cd.wins+cd.losses == 0 {
+		return 0
+	}
+	v := c.value(cd)
+	switch {
+	case c.Metric == "rate":
+		// Any day with games is shaded, by thirds up to 100%
+		if v >= 100 {
+			return 4
+		}
+		return 1 + v/34
+	case v == 0 || c.max == 0:
+		return 0
+	default:
+		return (4*v + c.max - 1) / c.max
+	}
+}
+
+// totals returns the games won and lost and the number of days with
+// games
+func (c *Calendar) totals() (wins, losses, days int) {
+	for _, cd := range c.days {
+		wins += cd.wins
+		losses += cd.losses
+		if cd.wins+cd.losses > 0 {
+			days++
+		}
+	}
+	return
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// startOfDay returns midnight at the start of the day of t
+func startOfDay(t time.Time) time.Time {
+	return model.PeriodDay.Start(t)
+}
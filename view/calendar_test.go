@@ -0,0 +1,81 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCalendar(t *testing.T, metric string) *Calendar {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 20, 0, 0, 0, time.UTC) }
+	sessions := []*model.Session{
+		{Start: day(4), End: day(4), Wins: 4},
+		{Start: day(5), End: day(5), Wins: 1, Losses: 1},
+		{Start: day(5).Add(2 * time.Hour), End: day(5), Losses: 2},
+		{Start: day(20), End: day(20), Wins: 1},
+	}
+	first := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	c, err := NewCalendar(sessions, first, last, metric)
+	assert.Nil(t, err)
+	return c
+}
+
+func TestCalendar_level(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   string
+		day      int
+		expected int
+	}{
+		{"no games", "games", 6, 0},
+		{"most games", "games", 4, 4},
+		{"two sessions", "games", 5, 4},
+		{"fewest games", "games", 20, 1},
+		{"most wins", "wins", 4, 4},
+		{"one win", "wins", 5, 1},
+		{"no wins", "wins", 6, 0},
+		{"all won", "rate", 4, 4},
+		{"quarter won", "rate", 5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCalendar(t, tt.metric)
+			assert.Equal(t, tt.expected, c.level(time.Date(2024, 3, tt.day, 0, 0, 0, 0, time.UTC)))
+		})
+	}
+}
+
+func TestCalendar_WriteText(t *testing.T) {
+	c := newTestCalendar(t, "games")
+	var buf bytes.Buffer
+	c.WriteText(&buf)
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Equal(t, 11, len(lines))
+	assert.Equal(t, "    Mar", lines[0])
+	assert.Equal(t, "Mon  █···", lines[1])
+	assert.Equal(t, "     █···", lines[2])
+	assert.Equal(t, "Wed  ··░·", lines[3])
+	assert.Equal(t, "Fri ·····", lines[5])
+	assert.Equal(t, "    Less ·░▒▓█ More", lines[9])
+	assert.Equal(t, "9 games played on 3 days, 6 won", lines[10])
+}
+
+func TestCalendar_WriteSVG(t *testing.T) {
+	c := newTestCalendar(t, "games")
+	var buf bytes.Buffer
+	c.WriteSVG(&buf)
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Equal(t, 31+5, strings.Count(svg, "<rect "))
+	assert.Contains(t, svg, "<title>2024-03-05: 4 played, 1 won</title>")
+}
+
+func TestNewCalendarInvalidMetric(t *testing.T) {
+	_, err := NewCalendar(nil, time.Now(), time.Now(), "losses")
+	assert.NotNil(t, err)
+}
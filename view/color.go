@@ -0,0 +1,118 @@
+package view
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// ColorMode says whether output should be colored
+type ColorMode int
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	ColorAuto   ColorMode = iota // Color only if stdout is a terminal
+	ColorAlways                  // Always color
+	ColorNever                   // Never color
+)
+
+// ANSI SGR codes
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+)
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+var (
+	// UseColor is true if ANSI colors should be written. Set it with
+	// SetColorMode.
+	UseColor = false
+
+	// PercentThreshold is the winning percentage at or above which the
+	// percentage is shown in green rather than red.
+	PercentThreshold = 50
+)
+
+var colorModeNames = map[ColorMode]string{
+	ColorAuto:   "auto",
+	ColorAlways: "always",
+	ColorNever:  "never",
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// String returns the name of the color mode, as accepted by
+// ParseColorMode.
+func (cm ColorMode) String() string {
+	return colorModeNames[cm]
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// ParseColorMode returns the color mode with the specified name, which
+// is one of "auto", "always", or "never".
+func ParseColorMode(name string) (ColorMode, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for cm, cmName := range colorModeNames {
+		if name == cmName {
+			return cm, nil
+		}
+	}
+	return ColorAuto, fmt.Errorf("invalid color mode %q", name)
+}
+
+// SetColorMode sets UseColor according to the mode. In auto mode,
+// color is used only if stdout is a terminal and NO_COLOR is not set.
+func SetColorMode(cm ColorMode) {
+	switch cm {
+	case ColorAlways:
+		UseColor = true
+	case ColorNever:
+		UseColor = false
+	default:
+		UseColor = os.Getenv("NO_COLOR") == "" && IsTerminal(os.Stdout)
+	}
+}
+
+// IsTerminal returns true if the file is a character device, such as a
+// terminal, rather than a pipe or a regular file.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps the string in the ANSI code, if colors are in use
+func colorize(s, code string) string {
+	if !UseColor {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// colorPercent colors a formatted percentage green if pct is at or
+// above the threshold, red otherwise.
+func colorPercent(s string, pct int) string {
+	if pct >= PercentThreshold {
+		return colorize(s, ansiGreen)
+	}
+	return colorize(s, ansiRed)
+}
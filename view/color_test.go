@@ -0,0 +1,56 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColorMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		expected      ColorMode
+		expectedError bool
+	}{
+		{"auto", ColorAuto, false},
+		{"Always", ColorAlways, false},
+		{"never", ColorNever, false},
+		{"sometimes", ColorAuto, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm, err := ParseColorMode(tt.name)
+			if tt.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, cm)
+			}
+		})
+	}
+}
+
+func TestSetColorMode(t *testing.T) {
+	defer SetColorMode(ColorNever)
+
+	SetColorMode(ColorAlways)
+	assert.True(t, UseColor)
+
+	SetColorMode(ColorNever)
+	assert.False(t, UseColor)
+
+	t.Setenv("NO_COLOR", "1")
+	SetColorMode(ColorAuto)
+	assert.False(t, UseColor)
+}
+
+func TestColorPercent(t *testing.T) {
+	defer SetColorMode(ColorNever)
+
+	SetColorMode(ColorNever)
+	assert.Equal(t, "45%", colorPercent("45%", 45))
+
+	SetColorMode(ColorAlways)
+	assert.Equal(t, ansiRed+"45%"+ansiReset, colorPercent("45%", 45))
+	assert.Equal(t, ansiGreen+"84%"+ansiReset, colorPercent("84%", 84))
+}
@@ -0,0 +1,122 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintDiff writes the changes in each of the specified games, or in
+// every game if none are specified, as a table. Only games that changed
+// are shown. Counts that went down are shown in red, because they mean
+// that statistics were reset or lost.
+func PrintDiff(w io.Writer, deltas map[string]model.Delta, gameNames ...string) {
+	sNames := []string{}
+	if len(gameNames) > 0 {
+		for _, gameName := range gameNames {
+			sName := model.ToSectionName(model.ToDisplayName(gameName))
+			if _, ok := deltas[sName]; ok {
+				sNames = append(sNames, sName)
+			}
+		}
+	} else {
+		for sName := range deltas {
+			sNames = append(sNames, sName)
+		}
+		sort.Strings(sNames)
+	}
+
+	header := []string{T("Game"), T("Played"), T("Wins"), T("Losses"), T("Win %"), T("Best"), T("Worst")}
+	rows := [][]string{}
+	changed := []model.Delta{}
+	wins, total, decreased := 0, 0, false
+	for _, sName := range sNames {
+		d := deltas[sName]
+		if !d.Changed() {
+			continue
+		}
+		rows = append(rows, []string{
+			model.ToDisplayName(sName),
+			diffInt(d.To.Total(), d.Total),
+			diffInt(d.To.Wins(), d.Wins),
+			diffInt(d.To.Losses(), d.Losses),
+			diffPercent(d),
+			diffTime(d.To, d.To.Best(), d.Best),
+			diffTime(d.To, d.To.Worst(), d.Worst),
+		})
+		changed = append(changed, d)
+		wins += d.Wins
+		total += d.Total
+		decreased = decreased || d.Decreased()
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, T("No differences"))
+		return
+	}
+	writeTable(w, header, rows, func(row, col int, cell string) string {
+		d := changed[row]
+		switch {
+		case col == 1 && d.Total < 0, col == 2 && d.Wins < 0, col == 3 && d.Losses < 0:
+			return colorize(cell, ansiRed)
+		case col == 5 && d.Best < 0:
+			return colorize(cell, ansiGreen)
+		}
+		return cell
+	})
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, T("%s games played, %s won")+"\n", signed(total), signed(wins))
+	if decreased {
+		fmt.Fprintln(w, T("Some counts went down, so statistics were reset or lost"))
+	}
+}
+
+// diffInt formats a count and its change, e.g., "176 (+1)"
+func diffInt(n, change int) string {
+	s := CurrentLocale.FormatInt(n)
+	if change != 0 {
+		s += " (" + signed(change) + ")"
+	}
+	return s
+}
+
+// diffPercent formats the winning percentage and its change in
+// percentage points, e.g., "84% (+0.4)"
+func diffPercent(d model.Delta) string {
+	s := CurrentLocale.FormatStatsPercent(d.To)
+	precision := PercentPrecision
+	if precision < 1 {
+		precision = 1
+	}
+	change := d.Percentage
+	if math.Abs(change) < 0.5*math.Pow(10, -float64(precision)) {
+		return s
+	}
+	sign := "+"
+	if change < 0 {
+		sign = "-"
+	}
+	return s + " (" + sign + CurrentLocale.FormatFloat(math.Abs(change), precision) + ")"
+}
+
+// diffTime formats a time and its change, e.g., "01:20 (-00:08)"
+func diffTime(ps *model.Statistics, seconds, change int) string {
+	s := statTime(ps, seconds)
+	switch {
+	case change > 0:
+		s += " (+" + SecondsToTime(change) + ")"
+	case change < 0:
+		s += " (-" + SecondsToTime(-change) + ")"
+	}
+	return s
+}
+
+// signed formats an integer with its sign, e.g., "+3", "-1", or "0"
+func signed(n int) string {
+	if n > 0 {
+		return "+" + CurrentLocale.FormatInt(n)
+	}
+	return CurrentLocale.FormatInt(n)
+}
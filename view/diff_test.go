@@ -0,0 +1,66 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintDiff(t *testing.T) {
+	snapshot := func(stats map[string]string) *model.Snapshot {
+		s := &model.Snapshot{Time: time.Now(), Stats: map[string]*model.Statistics{}}
+		for sName, statString := range stats {
+			s.Stats[sName], _ = model.NewStatisticsFromString(statString)
+		}
+		return s
+	}
+	before := snapshot(map[string]string{
+		"freecell.scm": "175;209;88;406;",
+		"spider.scm":   "45;244;479;907;",
+		"klondike.scm": "0;3;0;0;",
+	})
+	tests := []struct {
+		name      string
+		after     map[string]string
+		gameNames []string
+		want      []string
+	}{
+		{"no changes", map[string]string{
+			"freecell.scm": "175;209;88;406;",
+			"spider.scm":   "45;244;479;907;",
+			"klondike.scm": "0;3;0;0;",
+		}, nil, []string{"No differences"}},
+		{"some games", map[string]string{
+			"freecell.scm": "176;210;80;406;",
+			"spider.scm":   "45;244;479;907;",
+			"klondike.scm": "1;5;300;300;",
+		}, nil, []string{
+			"Game        Played      Wins  Losses        Win %            Best  Worst",
+			"Freecell  210 (+1)  176 (+1)      34   84% (+0.1)  01:20 (-00:08)  06:46",
+			"Klondike    5 (+2)    1 (+1)  4 (+1)  20% (+20.0)           05:00  05:00",
+			"",
+			"+3 games played, +2 won",
+		}},
+		{"selected game", map[string]string{
+			"freecell.scm": "176;210;80;406;",
+			"klondike.scm": "1;5;300;300;",
+		}, []string{"spider"}, []string{
+			"Game      Played     Wins    Losses       Win %  Best  Worst",
+			"Spider  0 (-244)  0 (-45)  0 (-199)  0% (-18.4)   N/A    N/A",
+			"",
+			"-244 games played, -45 won",
+			"Some counts went down, so statistics were reset or lost",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintDiff(&buf, before.Compare(snapshot(tt.after)), tt.gameNames...)
+			assert.Equal(t, tt.want, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"))
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// TimeFormat selects how a number of seconds is displayed
+type TimeFormat int
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	TimeClock   TimeFormat = iota // mm:ss, or h:mm:ss if an hour or more
+	TimeISO                       // ISO-8601 duration, e.g., PT1H2M7S
+	TimeCompact                   // Go duration, e.g., 2h0m7s
+	TimeSeconds                   // Raw seconds, e.g., 7207
+)
+
+// NoTime is displayed in place of a time when no winning game has been
+// recorded.
+const NoTime = "N/A"
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// DefaultTimeFormat is the format used by SecondsToTime, and therefore
+// by the text output and the "duration" template function.
+var DefaultTimeFormat = TimeClock
+
+var timeFormatNames = map[TimeFormat]string{
+	TimeClock:   "clock",
+	TimeISO:     "iso",
+	TimeCompact: "compact",
+	TimeSeconds: "seconds",
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// String returns the name of the time format, as accepted by
+// ParseTimeFormat.
+func (tf TimeFormat) String() string {
+	return timeFormatNames[tf]
+}
+
+// Format converts a number of seconds into a string in this format.
+func (tf TimeFormat) Format(seconds int) string {
+	switch tf {
+	case TimeISO:
+		return isoDuration(seconds)
+	case TimeCompact:
+		return (time.Duration(seconds) * time.Second).String()
+	case TimeSeconds:
+		return fmt.Sprintf("%d", seconds)
+	default:
+		return clockDuration(seconds)
+	}
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// ParseTimeFormat returns the time format with the specified name,
+// which is one of "clock", "iso", "compact", or "seconds".
+func ParseTimeFormat(name string) (TimeFormat, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for tf, tfName := range timeFormatNames {
+		if name == tfName {
+			return tf, nil
+		}
+	}
+	return TimeClock, fmt.Errorf("invalid time format %q", name)
+}
+
+// clockDuration formats seconds as mm:ss, or as h:mm:ss if the time is
+// an hour or more.
+func clockDuration(seconds int) string {
+	hh := seconds / 3600
+	mm := (seconds % 3600) / 60
+	ss := seconds % 60
+	if hh > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hh, mm, ss)
+	}
+	return fmt.Sprintf("%02d:%02d", mm, ss)
+}
+
+// isoDuration formats seconds as an ISO-8601 duration, omitting zero
+// components.
+func isoDuration(seconds int) string {
+	if seconds == 0 {
+		return "PT0S"
+	}
+	hh := seconds / 3600
+	mm := (seconds % 3600) / 60
+	ss := seconds % 60
+	var sb strings.Builder
+	sb.WriteString("PT")
+	if hh > 0 {
+		fmt.Fprintf(&sb, "%dH", hh)
+	}
+	if mm > 0 {
+		fmt.Fprintf(&sb, "%dM", mm)
+	}
+	if ss > 0 {
+		fmt.Fprintf(&sb, "%dS", ss)
+	}
+	return sb.String()
+}
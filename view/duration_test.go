@@ -0,0 +1,56 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeFormat_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		tf       TimeFormat
+		seconds  int
+		expected string
+	}{
+		{"clock zero", TimeClock, 0, "00:00"},
+		{"clock minutes", TimeClock, 479, "07:59"},
+		{"clock hours", TimeClock, 7207, "2:00:07"},
+		{"iso zero", TimeISO, 0, "PT0S"},
+		{"iso minutes", TimeISO, 479, "PT7M59S"},
+		{"iso hours", TimeISO, 3720, "PT1H2M"},
+		{"compact zero", TimeCompact, 0, "0s"},
+		{"compact hours", TimeCompact, 7207, "2h0m7s"},
+		{"seconds", TimeSeconds, 7207, "7207"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.tf.Format(tt.seconds))
+		})
+	}
+}
+
+func TestParseTimeFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		expected      TimeFormat
+		expectedError bool
+	}{
+		{"clock", TimeClock, false},
+		{"ISO", TimeISO, false},
+		{" compact ", TimeCompact, false},
+		{"seconds", TimeSeconds, false},
+		{"bogus", TimeClock, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := ParseTimeFormat(tt.name)
+			if tt.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, tf)
+			}
+		})
+	}
+}
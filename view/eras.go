@@ -0,0 +1,65 @@
+package view
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintEras writes the statistics between resets for each of the
+// specified games, or for every game in the history if none are
+// specified. Games that were reset also get a lifetime total.
+func PrintEras(w io.Writer, history *model.History, gameNames ...string) {
+	if len(history.Snapshots) == 0 {
+		fmt.Fprintln(w, T("No history has been recorded"))
+		return
+	}
+	sNames := history.Sections()
+	if len(gameNames) > 0 {
+		sNames = []string{}
+		for _, gameName := range gameNames {
+			sNames = append(sNames, model.ToSectionName(model.ToDisplayName(gameName)))
+		}
+	}
+	header := []string{T("Era"), T("Played"), T("Wins"), T("Win %"), T("Best")}
+	for i, sName := range sNames {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, colorize(model.ToDisplayName(sName), ansiBold))
+		eras := history.Eras(sName)
+		stats := []*model.Statistics{}
+		rows := [][]string{}
+		for _, era := range eras {
+			stats = append(stats, era.Stats)
+			rows = append(rows, eraRow(fmt.Sprintf("%s – %s",
+				era.Start.Local().Format("2006-01-02"),
+				era.End.Local().Format("2006-01-02")), era.Stats))
+		}
+		if len(eras) > 1 {
+			ps := history.Lifetime(sName)
+			stats = append(stats, ps)
+			rows = append(rows, eraRow(T("Lifetime"), ps))
+		}
+		const pctColumn = 3
+		writeTable(w, header, rows, func(row, col int, cell string) string {
+			if col == pctColumn {
+				return colorPercent(cell, stats[row].Percentage())
+			}
+			return cell
+		})
+	}
+}
+
+// eraRow returns the cells of one row of the eras table
+func eraRow(label string, ps *model.Statistics) []string {
+	loc := CurrentLocale
+	return []string{
+		label,
+		loc.FormatInt(ps.Total()),
+		loc.FormatInt(ps.Wins()),
+		loc.FormatStatsPercent(ps),
+		statTime(ps, ps.Best()),
+	}
+}
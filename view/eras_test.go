@@ -0,0 +1,36 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintEras(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	history := &model.History{}
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, statString := range []string{"5;10;60;90;", "6;11;60;90;", "1;1;50;50;", "2;4;50;80;"} {
+		ps, err := model.NewStatisticsFromString(statString)
+		assert.Nil(t, err)
+		history.Add(&model.Snapshot{
+			Time:  t0.AddDate(0, 0, i),
+			Stats: map[string]*model.Statistics{"freecell.scm": ps},
+		})
+	}
+	var buf bytes.Buffer
+	PrintEras(&buf, history)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, "Freecell", lines[0])
+	assert.True(t, strings.HasPrefix(lines[3], "2024-03-03 – 2024-03-04"), lines[3])
+	assert.Equal(t, []string{"Lifetime", "15", "8", "53%", "00:50"}, strings.Fields(lines[4]))
+
+	buf.Reset()
+	PrintEras(&buf, &model.History{})
+	assert.Equal(t, "No history has been recorded\n", buf.String())
+}
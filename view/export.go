@@ -0,0 +1,153 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Export is the JSON document written by ExportJSON
+type Export struct {
+	Recent []string      `json:"recent"` // Section names, most recent first
+	Games  []*ExportGame `json:"games"`  // Every game with statistics
+}
+
+// ExportGame holds the statistics for one game in an Export. The times
+// are null when no game has been won.
+type ExportGame struct {
+	Name       string  `json:"name"`
+	Section    string  `json:"section"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	Total      int     `json:"total"`
+	Percentage int     `json:"percentage"`
+	Best       *string `json:"best"`
+	Average    *string `json:"average"`
+	Worst      *string `json:"worst"`
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Snapshot returns the statistics in the export, with the time t. Games
+// whose statistics are impossible are rejected, as they are in a
+// statistics file.
+func (export *Export) Snapshot(t time.Time) (*model.Snapshot, error) {
+	snapshot := &model.Snapshot{
+		Time:  t.UTC().Truncate(time.Second),
+		Stats: map[string]*model.Statistics{},
+	}
+	for _, game := range export.Games {
+		var best, worst int
+		var err error
+		if game.Best != nil {
+			if best, err = model.ParseSeconds(*game.Best); err != nil {
+				return nil, fmt.Errorf("%s: %v", game.Section, err)
+			}
+		}
+		if game.Worst != nil {
+			if worst, err = model.ParseSeconds(*game.Worst); err != nil {
+				return nil, fmt.Errorf("%s: %v", game.Section, err)
+			}
+		}
+		ps, err := model.NewValidStatistics(game.Wins, game.Total, best, worst)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", game.Section, err)
+		}
+		snapshot.Stats[game.Section] = ps
+	}
+	return snapshot, nil
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// NewExport collects the statistics for the specified games, or for
+// every game if none are specified. Times are formatted with the
+// default time format.
+func NewExport(pdp *model.DataProvider, gameNames ...string) (*Export, error) {
+	export := &Export{
+		Recent: []string{},
+		Games:  []*ExportGame{},
+	}
+	for _, gameName := range pdp.GameList() {
+		export.Recent = append(export.Recent, model.ToSectionName(model.ToDisplayName(gameName)))
+	}
+	for _, sName := range gameSections(pdp, gameNames) {
+		td, err := NewTemplateData(pdp, model.ToDisplayName(sName))
+		if err != nil {
+			return nil, err
+		}
+		export.Games = append(export.Games, NewExportGame(td))
+	}
+	return export, nil
+}
+
+// NewExportGame returns the statistics for one game
+func NewExportGame(td *TemplateData) *ExportGame {
+	ps := td.Stats
+	game := &ExportGame{
+		Name:       td.Name,
+		Section:    td.Section,
+		Wins:       ps.Wins(),
+		Losses:     ps.Losses(),
+		Total:      ps.Total(),
+		Percentage: ps.Percentage(),
+	}
+	if ps.HasTimes() {
+		game.Best = exportTime(ps.Best())
+		game.Average = exportTime(ps.Average())
+		game.Worst = exportTime(ps.Worst())
+	}
+	return game
+}
+
+// ReadExport reads a document written by ExportJSON
+func ReadExport(r io.Reader) (*Export, error) {
+	export := &Export{}
+	if err := json.NewDecoder(r).Decode(export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// ExportJSON writes the statistics for the specified games, or for
+// every game if none are specified, as indented JSON
+func ExportJSON(w io.Writer, pdp *model.DataProvider, gameNames ...string) error {
+	export, err := NewExport(pdp, gameNames...)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, export)
+}
+
+// PrintJSON writes the statistics for one game as indented JSON
+func PrintJSON(w io.Writer, pdp *model.DataProvider, gameName string) error {
+	td, err := NewTemplateData(pdp, gameName)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, NewExportGame(td))
+}
+
+// writeJSON writes a value as indented JSON
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// exportTime formats a time for an ExportGame
+func exportTime(seconds int) *string {
+	s := SecondsToTime(seconds)
+	return &s
+}
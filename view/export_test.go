@@ -0,0 +1,84 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportJSON(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	err = ExportJSON(&buf, pdp)
+	assert.Nil(t, err)
+
+	var export Export
+	err = json.Unmarshal(buf.Bytes(), &export)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"spider.scm", "freecell.scm", "canfield.scm", "klondike.scm"}, export.Recent)
+	assert.Equal(t, 4, len(export.Games))
+
+	freecell := export.Games[1]
+	assert.Equal(t, "Freecell", freecell.Name)
+	assert.Equal(t, 175, freecell.Wins)
+	assert.Equal(t, 84, freecell.Percentage)
+	assert.Equal(t, "01:28", *freecell.Best)
+
+	klondike := export.Games[2]
+	assert.Equal(t, "Klondike", klondike.Name)
+	assert.Nil(t, klondike.Best)
+	assert.Contains(t, buf.String(), `"best": null`)
+}
+
+func TestPrintJSON(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	err = PrintJSON(&buf, pdp, "Freecell")
+	assert.Nil(t, err)
+
+	var game ExportGame
+	err = json.Unmarshal(buf.Bytes(), &game)
+	assert.Nil(t, err)
+	assert.Equal(t, "freecell.scm", game.Section)
+	assert.Equal(t, 209, game.Total)
+}
+
+func TestExport_Snapshot(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	want, err := model.NewSnapshot(time.Now(), pdp)
+	assert.Nil(t, err)
+
+	saved := DefaultTimeFormat
+	defer func() { DefaultTimeFormat = saved }()
+	for _, tf := range []TimeFormat{TimeClock, TimeISO, TimeCompact, TimeSeconds} {
+		t.Run(tf.String(), func(t *testing.T) {
+			DefaultTimeFormat = tf
+			var buf bytes.Buffer
+			assert.Nil(t, ExportJSON(&buf, pdp))
+			export, err := ReadExport(&buf)
+			assert.Nil(t, err)
+			have, err := export.Snapshot(want.Time)
+			assert.Nil(t, err)
+			assert.True(t, want.Equal(have))
+		})
+	}
+}
+
+func TestExport_SnapshotInvalid(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(`{"recent": [], "games": [
+		{"name": "Spider", "section": "spider.scm", "wins": 9, "total": 5, "best": "01:00", "worst": "00:30"}
+	]}`))
+	assert.Nil(t, err)
+	_, err = export.Snapshot(time.Now())
+	assert.ErrorIs(t, err, model.ErrImpossible)
+	assert.ErrorContains(t, err, "spider.scm: impossible statistics: wins (9) greater than total (5)")
+}
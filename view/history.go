@@ -0,0 +1,51 @@
+package view
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintHistory writes the statistics for one game in each snapshot in
+// which they changed, oldest first. Snapshots in which the game was
+// reset are marked.
+func PrintHistory(w io.Writer, history *model.History, gameName string) {
+	sName := model.ToSectionName(model.ToDisplayName(gameName))
+	loc := CurrentLocale
+	header := []string{T("Time"), T("Played"), T("Wins"), T("Win %"), T("Best")}
+	rows := [][]string{}
+	stats := []*model.Statistics{}
+	var prev *model.Statistics
+	for i, sample := range history.Series(sName) {
+		ps := sample.Stats
+		if prev != nil && *prev == *ps || prev == nil && ps.Total() == 0 {
+			continue
+		}
+		prev = ps
+		when := sample.Time.Local().Format("2006-01-02 15:04")
+		if history.Snapshots[i].Reset(sName) {
+			when += " (" + T("reset") + ")"
+		}
+		rows = append(rows, []string{
+			when,
+			loc.FormatInt(ps.Total()),
+			loc.FormatInt(ps.Wins()),
+			loc.FormatStatsPercent(ps),
+			statTime(ps, ps.Best()),
+		})
+		stats = append(stats, ps)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintf(w, T("No history has been recorded for %s")+"\n", model.ToDisplayName(gameName))
+		return
+	}
+	fmt.Fprintln(w, colorize(model.ToDisplayName(gameName), ansiBold))
+	const pctColumn = 3
+	writeTable(w, header, rows, func(row, col int, cell string) string {
+		if col == pctColumn {
+			return colorPercent(cell, stats[row].Percentage())
+		}
+		return cell
+	})
+}
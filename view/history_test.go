@@ -0,0 +1,42 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintHistory(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	history := &model.History{}
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, statStrings := range [][]string{
+		{"0;0;0;0;", "1;1;30;30;"},
+		{"5;10;60;90;", "1;1;30;30;"},
+		{"5;10;60;90;", "2;2;30;40;"},
+		{"1;1;50;50;", "2;2;30;40;"},
+	} {
+		snapshot := &model.Snapshot{Time: t0.AddDate(0, 0, i), Stats: map[string]*model.Statistics{}}
+		for j, sName := range []string{"freecell.scm", "spider.scm"} {
+			ps, err := model.NewStatisticsFromString(statStrings[j])
+			assert.Nil(t, err)
+			snapshot.Stats[sName] = ps
+		}
+		history.Add(snapshot)
+	}
+	var buf bytes.Buffer
+	PrintHistory(&buf, history, "freecell")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, "Freecell", lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], "2024-03-02"), lines[2])
+	assert.Contains(t, lines[3], "(reset)")
+
+	buf.Reset()
+	PrintHistory(&buf, history, "klondike")
+	assert.Equal(t, "No history has been recorded for Klondike\n", buf.String())
+}
@@ -0,0 +1,497 @@
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Locale holds the translated messages and number formatting rules for
+// one language. Messages are keyed by their English text.
+type Locale struct {
+	Name      string            // Language code, e.g., "de"
+	Thousands string            // Digit group separator
+	Decimal   string            // Decimal separator
+	Percent   string            // Format for a formatted number and "%"
+	DateTime  string            // Layout of a date and time, for time.Format
+	messages  map[string]string // English text to translated text
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// Locales are the supported languages, keyed by language code.
+var Locales = map[string]*Locale{
+	"en": {
+		Name:      "en",
+		Thousands: ",",
+		Decimal:   ".",
+		Percent:   "%s%%",
+		DateTime:  "2006-01-02 15:04:05",
+	},
+	"de": {
+		Name:      "de",
+		Thousands: ".",
+		Decimal:   ",",
+		Percent:   "%s\u00a0%%",
+		DateTime:  "02.01.2006 15:04:05",
+		messages: map[string]string{
+			"Game name:":                       "Spielname:",
+			"Number of wins:":                  "Anzahl der Siege:",
+			"Number of losses:":                "Anzahl der Niederlagen:",
+			"Total games played:":              "Gespielte Spiele insgesamt:",
+			"Best time:":                       "Beste Zeit:",
+			"Average time:":                    "Durchschnittliche Zeit:",
+			"Worst time:":                      "Schlechteste Zeit:",
+			"Winning percentage:":              "Gewinnquote:",
+			"Number of wins to %s:":            "Siege bis %s:",
+			"Number of losses to %s:":          "Niederlagen bis %s:",
+			"No games have been played":        "Es wurden noch keine Spiele gespielt",
+			NoTime:                             "k. A.",
+			"Game":                             "Spiel",
+			"Played":                           "Gespielt",
+			"Wins":                             "Siege",
+			"Losses":                           "Niederlagen",
+			"Win %":                            "Quote",
+			"Best":                             "Beste",
+			"Average":                          "Mittel",
+			"Worst":                            "Schlechteste",
+			"%s: %s cannot be reached":         "%s: %s ist nicht erreichbar",
+			"%s: already at %s":                "%s: bereits bei %s",
+			"%s: %s wins in a row to reach %s": "%s: %s Siege in Folge bis %s",
+			"Current streak":                   "Aktuelle Serie",
+			"Longest winning streak":           "Längste Siegesserie",
+			"Longest losing streak":            "Längste Niederlagenserie",
+			"%s won":                           "%s gewonnen",
+			"%s lost":                          "%s verloren",
+			"≥ means at least, because several games were played between snapshots": "≥ bedeutet mindestens, weil zwischen zwei Aufnahmen mehrere Spiele gespielt wurden",
+			"No history has been recorded":                                          "Es wurde noch kein Verlauf aufgezeichnet",
+			"New best":                                                              "Neue Bestzeit",
+			"No games were played in this time":                                     "In diesem Zeitraum wurden keine Spiele gespielt",
+			"Jan":                                                                   "Jan",
+			"Feb":                                                                   "Feb",
+			"Mar":                                                                   "Mär",
+			"Apr":                                                                   "Apr",
+			"May":                                                                   "Mai",
+			"Jun":                                                                   "Jun",
+			"Jul":                                                                   "Jul",
+			"Aug":                                                                   "Aug",
+			"Sep":                                                                   "Sep",
+			"Oct":                                                                   "Okt",
+			"Nov":                                                                   "Nov",
+			"Dec":                                                                   "Dez",
+			"Mon":                                                                   "Mo",
+			"Wed":                                                                   "Mi",
+			"Fri":                                                                   "Fr",
+			"Less":                                                                  "Weniger",
+			"More":                                                                  "Mehr",
+			"%s games played on %s days, %s won":                                    "%s Spiele an %s Tagen gespielt, %s gewonnen",
+			"%s: %s played, %s won":                                                 "%s: %s gespielt, %s gewonnen",
+			"First win":                                                             "Erster Sieg",
+			"Win a game":                                                            "Ein Spiel gewinnen",
+			"Explorer":                                                              "Entdecker",
+			"Play 20 different games":                                               "20 verschiedene Spiele spielen",
+			"Centurion":                                                             "Zenturio",
+			"Win 100 games of one game":                                             "100 Partien eines Spiels gewinnen",
+			"Marathon":                                                              "Marathon",
+			"Play 1,000 games":                                                      "1.000 Partien spielen",
+			"Steady hand":                                                           "Ruhige Hand",
+			"Reach 50% in a game with 200 or more games played": "50 % in einem Spiel mit mindestens 200 Partien erreichen",
+			"Speed demon":                      "Blitzschnell",
+			"Win a game in under 2 minutes":    "Eine Partie in unter 2 Minuten gewinnen",
+			"Hot streak":                       "Glückssträhne",
+			"Win 5 games of one game in a row": "5 Partien eines Spiels in Folge gewinnen",
+			"%s of %s unlocked":                "%s von %s freigeschaltet",
+			"New best time":                    "Neue Bestzeit",
+			" (was %s)":                        " (vorher %s)",
+			"Winning percentage up":            "Gewinnquote gestiegen",
+			"Milestone":                        "Meilenstein",
+			"%s: %s games played":              "%s: %s Partien gespielt",
+			"Achievement unlocked":             "Erfolg freigeschaltet",
+			"No differences":                   "Keine Unterschiede",
+			"%s games played, %s won":          "%s Spiele gespielt, %s gewonnen",
+			"Some counts went down, so statistics were reset or lost": "Einige Zahlen sind gesunken, also wurden Statistiken zurückgesetzt oder verloren",
+			"No snapshot was found":                                   "Keine Aufnahme gefunden",
+			"All games (at least %s played)":                          "Alle Spiele (mindestens %s gespielt)",
+			"%s (at least %s played)":                                 "%s (mindestens %s gespielt)",
+			"No players have played enough games":                     "Kein Spieler hat genug Spiele gespielt",
+			"Player":                                                  "Spieler",
+			"No users with statistics were found":                     "Es wurden keine Benutzer mit Statistiken gefunden",
+			"User":                                                    "Benutzer",
+			"Games":                                                   "Spiele",
+			"Cannot read the statistics of %s: %v":                    "Die Statistiken von %s können nicht gelesen werden: %v",
+			"%s: statistics reset for %s":                             "%s: Statistiken zurückgesetzt für %s",
+			"%s of %s snapshots added":                                "%s von %s Aufnahmen hinzugefügt",
+			"Era":                                                     "Ära",
+			"Lifetime":                                                "Gesamt",
+			"The statistics of %s games are valid":                    "Die Statistiken von %s Spielen sind gültig",
+			"Nothing to repair":                                       "Nichts zu reparieren",
+			"Repaired %s; the original is in %s":                      "%s wurde repariert; das Original ist in %s",
+			"not recently played":                                     "nicht kürzlich gespielt",
+			"no statistics":                                           "keine Statistiken",
+			"Time":                                                    "Zeit",
+			"reset":                                                   "zurückgesetzt",
+			"No history has been recorded for %s":                     "Für %s wurde kein Verlauf aufgezeichnet",
+			"The statistics of %s game are valid":                     "Die Statistik von %s Spiel ist gültig",
+			"%s game has invalid statistics":                          "%s Spiel hat ungültige Statistiken",
+			"%s games have invalid statistics":                        "%s Spiele haben ungültige Statistiken",
+			"Some statistics are impossible and were not repaired; see arstats check": "Einige Statistiken sind unmöglich und wurden nicht repariert; siehe arstats check",
+			"%s of %s files was rejected":  "%s von %s Dateien wurde abgelehnt",
+			"%s of %s files were rejected": "%s von %s Dateien wurden abgelehnt",
+			"%s: recorded snapshot":        "%s: Aufnahme gespeichert",
+		},
+	},
+	"fr": {
+		Name:      "fr",
+		Thousands: "\u202f",
+		Decimal:   ",",
+		Percent:   "%s\u00a0%%",
+		DateTime:  "02/01/2006 15:04:05",
+		messages: map[string]string{
+			"Game name:":                       "Nom du jeu :",
+			"Number of wins:":                  "Nombre de victoires :",
+			"Number of losses:":                "Nombre de défaites :",
+			"Total games played:":              "Total des parties jouées :",
+			"Best time:":                       "Meilleur temps :",
+			"Average time:":                    "Temps moyen :",
+			"Worst time:":                      "Pire temps :",
+			"Winning percentage:":              "Pourcentage de victoires :",
+			"Number of wins to %s:":            "Victoires jusqu'à %s :",
+			"Number of losses to %s:":          "Défaites jusqu'à %s :",
+			"No games have been played":        "Aucune partie n'a été jouée",
+			NoTime:                             "N/D",
+			"Game":                             "Jeu",
+			"Played":                           "Jouées",
+			"Wins":                             "Victoires",
+			"Losses":                           "Défaites",
+			"Win %":                            "% victoires",
+			"Best":                             "Meilleur",
+			"Average":                          "Moyen",
+			"Worst":                            "Pire",
+			"%s: %s cannot be reached":         "%s : %s est impossible à atteindre",
+			"%s: already at %s":                "%s : déjà à %s",
+			"%s: %s wins in a row to reach %s": "%s : %s victoires d'affilée pour atteindre %s",
+			"Current streak":                   "Série en cours",
+			"Longest winning streak":           "Plus longue série de victoires",
+			"Longest losing streak":            "Plus longue série de défaites",
+			"%s won":                           "%s gagnées",
+			"%s lost":                          "%s perdues",
+			"≥ means at least, because several games were played between snapshots": "≥ signifie au moins, car plusieurs parties ont été jouées entre deux instantanés",
+			"No history has been recorded":                                          "Aucun historique n’a été enregistré",
+			"New best":                                                              "Nouveau record",
+			"No games were played in this time":                                     "Aucune partie n’a été jouée pendant cette période",
+			"Jan":                                                                   "janv",
+			"Feb":                                                                   "févr",
+			"Mar":                                                                   "mars",
+			"Apr":                                                                   "avr",
+			"May":                                                                   "mai",
+			"Jun":                                                                   "juin",
+			"Jul":                                                                   "juil",
+			"Aug":                                                                   "août",
+			"Sep":                                                                   "sept",
+			"Oct":                                                                   "oct",
+			"Nov":                                                                   "nov",
+			"Dec":                                                                   "déc",
+			"Mon":                                                                   "lun",
+			"Wed":                                                                   "mer",
+			"Fri":                                                                   "ven",
+			"Less":                                                                  "Moins",
+			"More":                                                                  "Plus",
+			"%s games played on %s days, %s won":                                    "%s parties jouées sur %s jours, %s gagnées",
+			"%s: %s played, %s won":                                                 "%s : %s jouées, %s gagnées",
+			"First win":                                                             "Première victoire",
+			"Win a game":                                                            "Gagner une partie",
+			"Explorer":                                                              "Explorateur",
+			"Play 20 different games":                                               "Jouer à 20 jeux différents",
+			"Centurion":                                                             "Centurion",
+			"Win 100 games of one game":                                             "Gagner 100 parties d’un même jeu",
+			"Marathon":                                                              "Marathon",
+			"Play 1,000 games":                                                      "Jouer 1 000 parties",
+			"Steady hand":                                                           "Main sûre",
+			"Reach 50% in a game with 200 or more games played": "Atteindre 50 % à un jeu avec au moins 200 parties",
+			"Speed demon":                      "Éclair",
+			"Win a game in under 2 minutes":    "Gagner une partie en moins de 2 minutes",
+			"Hot streak":                       "Série gagnante",
+			"Win 5 games of one game in a row": "Gagner 5 parties d’un même jeu d’affilée",
+			"%s of %s unlocked":                "%s sur %s débloqués",
+			"New best time":                    "Nouveau record",
+			" (was %s)":                        " (avant %s)",
+			"Winning percentage up":            "Pourcentage de victoires en hausse",
+			"Milestone":                        "Étape franchie",
+			"%s: %s games played":              "%s : %s parties jouées",
+			"Achievement unlocked":             "Succès débloqué",
+			"No differences":                   "Aucune différence",
+			"%s games played, %s won":          "%s parties jouées, %s gagnées",
+			"Some counts went down, so statistics were reset or lost": "Certains nombres ont baissé : des statistiques ont été réinitialisées ou perdues",
+			"No snapshot was found":                                   "Aucun instantané trouvé",
+			"All games (at least %s played)":                          "Tous les jeux (au moins %s parties)",
+			"%s (at least %s played)":                                 "%s (au moins %s parties)",
+			"No players have played enough games":                     "Aucun joueur n’a joué assez de parties",
+			"Player":                                                  "Joueur",
+			"No users with statistics were found":                     "Aucun utilisateur avec des statistiques n’a été trouvé",
+			"User":                                                    "Utilisateur",
+			"Games":                                                   "Jeux",
+			"Cannot read the statistics of %s: %v":                    "Impossible de lire les statistiques de %s : %v",
+			"%s: statistics reset for %s":                             "%s : statistiques réinitialisées pour %s",
+			"%s of %s snapshots added":                                "%s instantanés ajoutés sur %s",
+			"Era":                                                     "Ère",
+			"Lifetime":                                                "Au total",
+			"The statistics of %s games are valid":                    "Les statistiques de %s jeux sont valides",
+			"Nothing to repair":                                       "Rien à réparer",
+			"Repaired %s; the original is in %s":                      "%s a été réparé ; l’original est dans %s",
+			"not recently played":                                     "pas joué récemment",
+			"no statistics":                                           "pas de statistiques",
+			"Time":                                                    "Heure",
+			"reset":                                                   "réinitialisé",
+			"No history has been recorded for %s":                     "Aucun historique n’a été enregistré pour %s",
+			"The statistics of %s game are valid":                     "Les statistiques de %s jeu sont valides",
+			"%s game has invalid statistics":                          "%s jeu a des statistiques invalides",
+			"%s games have invalid statistics":                        "%s jeux ont des statistiques invalides",
+			"Some statistics are impossible and were not repaired; see arstats check": "Certaines statistiques sont impossibles et n’ont pas été réparées ; voir arstats check",
+			"%s of %s files was rejected":  "%s fichier sur %s a été rejeté",
+			"%s of %s files were rejected": "%s fichiers sur %s ont été rejetés",
+			"%s: recorded snapshot":        "%s : instantané enregistré",
+		},
+	},
+	"es": {
+		Name:      "es",
+		Thousands: ".",
+		Decimal:   ",",
+		Percent:   "%s\u00a0%%",
+		DateTime:  "02/01/2006 15:04:05",
+		messages: map[string]string{
+			"Game name:":                       "Nombre del juego:",
+			"Number of wins:":                  "Número de victorias:",
+			"Number of losses:":                "Número de derrotas:",
+			"Total games played:":              "Total de partidas jugadas:",
+			"Best time:":                       "Mejor tiempo:",
+			"Average time:":                    "Tiempo medio:",
+			"Worst time:":                      "Peor tiempo:",
+			"Winning percentage:":              "Porcentaje de victorias:",
+			"Number of wins to %s:":            "Victorias hasta %s:",
+			"Number of losses to %s:":          "Derrotas hasta %s:",
+			"No games have been played":        "No se ha jugado ninguna partida",
+			NoTime:                             "N/D",
+			"Game":                             "Juego",
+			"Played":                           "Jugadas",
+			"Wins":                             "Victorias",
+			"Losses":                           "Derrotas",
+			"Win %":                            "% victorias",
+			"Best":                             "Mejor",
+			"Average":                          "Medio",
+			"Worst":                            "Peor",
+			"%s: %s cannot be reached":         "%s: no se puede alcanzar %s",
+			"%s: already at %s":                "%s: ya está en %s",
+			"%s: %s wins in a row to reach %s": "%s: %s victorias seguidas para llegar a %s",
+			"Current streak":                   "Racha actual",
+			"Longest winning streak":           "Racha de victorias más larga",
+			"Longest losing streak":            "Racha de derrotas más larga",
+			"%s won":                           "%s ganadas",
+			"%s lost":                          "%s perdidas",
+			"≥ means at least, because several games were played between snapshots": "≥ significa al menos, porque se jugaron varias partidas entre dos instantáneas",
+			"No history has been recorded":                                          "Todavía no se ha registrado ningún historial",
+			"New best":                                                              "Nuevo récord",
+			"No games were played in this time":                                     "No se jugó ninguna partida en este periodo",
+			"Jan":                                                                   "ene",
+			"Feb":                                                                   "feb",
+			"Mar":                                                                   "mar",
+			"Apr":                                                                   "abr",
+			"May":                                                                   "may",
+			"Jun":                                                                   "jun",
+			"Jul":                                                                   "jul",
+			"Aug":                                                                   "ago",
+			"Sep":                                                                   "sep",
+			"Oct":                                                                   "oct",
+			"Nov":                                                                   "nov",
+			"Dec":                                                                   "dic",
+			"Mon":                                                                   "lun",
+			"Wed":                                                                   "mié",
+			"Fri":                                                                   "vie",
+			"Less":                                                                  "Menos",
+			"More":                                                                  "Más",
+			"%s games played on %s days, %s won":                                    "%s partidas jugadas en %s días, %s ganadas",
+			"%s: %s played, %s won":                                                 "%s: %s jugadas, %s ganadas",
+			"First win":                                                             "Primera victoria",
+			"Win a game":                                                            "Ganar una partida",
+			"Explorer":                                                              "Explorador",
+			"Play 20 different games":                                               "Jugar a 20 juegos distintos",
+			"Centurion":                                                             "Centurión",
+			"Win 100 games of one game":                                             "Ganar 100 partidas de un mismo juego",
+			"Marathon":                                                              "Maratón",
+			"Play 1,000 games":                                                      "Jugar 1.000 partidas",
+			"Steady hand":                                                           "Pulso firme",
+			"Reach 50% in a game with 200 or more games played": "Llegar al 50 % en un juego con 200 partidas o más",
+			"Speed demon":                      "Relámpago",
+			"Win a game in under 2 minutes":    "Ganar una partida en menos de 2 minutos",
+			"Hot streak":                       "Racha ganadora",
+			"Win 5 games of one game in a row": "Ganar 5 partidas seguidas de un mismo juego",
+			"%s of %s unlocked":                "%s de %s desbloqueados",
+			"New best time":                    "Nuevo mejor tiempo",
+			" (was %s)":                        " (antes %s)",
+			"Winning percentage up":            "Sube el porcentaje de victorias",
+			"Milestone":                        "Hito",
+			"%s: %s games played":              "%s: %s partidas jugadas",
+			"Achievement unlocked":             "Logro desbloqueado",
+			"No differences":                   "Sin diferencias",
+			"%s games played, %s won":          "%s partidas jugadas, %s ganadas",
+			"Some counts went down, so statistics were reset or lost": "Algunos números bajaron, así que se reiniciaron o perdieron estadísticas",
+			"No snapshot was found":                                   "No se encontró ninguna instantánea",
+			"All games (at least %s played)":                          "Todos los juegos (al menos %s partidas)",
+			"%s (at least %s played)":                                 "%s (al menos %s partidas)",
+			"No players have played enough games":                     "Ningún jugador ha jugado suficientes partidas",
+			"Player":                                                  "Jugador",
+			"No users with statistics were found":                     "No se encontraron usuarios con estadísticas",
+			"User":                                                    "Usuario",
+			"Games":                                                   "Juegos",
+			"Cannot read the statistics of %s: %v":                    "No se pueden leer las estadísticas de %s: %v",
+			"%s: statistics reset for %s":                             "%s: estadísticas reiniciadas para %s",
+			"%s of %s snapshots added":                                "%s de %s instantáneas añadidas",
+			"Era":                                                     "Era",
+			"Lifetime":                                                "Total histórico",
+			"The statistics of %s games are valid":                    "Las estadísticas de %s juegos son válidas",
+			"Nothing to repair":                                       "Nada que reparar",
+			"Repaired %s; the original is in %s":                      "%s se ha reparado; el original está en %s",
+			"not recently played":                                     "no jugado recientemente",
+			"no statistics":                                           "sin estadísticas",
+			"Time":                                                    "Hora",
+			"reset":                                                   "reiniciado",
+			"No history has been recorded for %s":                     "No se ha registrado historial para %s",
+			"The statistics of %s game are valid":                     "Las estadísticas de %s juego son válidas",
+			"%s game has invalid statistics":                          "%s juego tiene estadísticas no válidas",
+			"%s games have invalid statistics":                        "%s juegos tienen estadísticas no válidas",
+			"Some statistics are impossible and were not repaired; see arstats check": "Algunas estadísticas son imposibles y no se repararon; consulte arstats check",
+			"%s of %s files was rejected":  "Se rechazó %s de %s archivos",
+			"%s of %s files were rejected": "Se rechazaron %s de %s archivos",
+			"%s: recorded snapshot":        "%s: instantánea guardada",
+		},
+	},
+}
+
+// CurrentLocale is the locale used for all output.
+var CurrentLocale = Locales["en"]
+
+// PercentPrecision is the number of decimal places shown in winning
+// percentages. With zero, they are rounded to the nearest integer.
+var PercentPrecision = 0
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// T returns the translation of the English message, or the message
+// itself if there is no translation.
+func (loc *Locale) T(msg string) string {
+	if s, ok := loc.messages[msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// TN translates the singular message if n is 1, and the plural message
+// otherwise
+func (loc *Locale) TN(n int, singular, plural string) string {
+	if n == 1 {
+		return loc.T(singular)
+	}
+	return loc.T(plural)
+}
+
+// FormatDateTime formats a time as a date and time in the local time
+// zone, e.g., "2024-03-01 20:15:00" or "01.03.2024 20:15:00"
+func (loc *Locale) FormatDateTime(t time.Time) string {
+	return t.Local().Format(loc.DateTime)
+}
+
+// FormatInt formats an integer with digit grouping, e.g., "1,234"
+func (loc *Locale) FormatInt(n int) string {
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	digits := fmt.Sprintf("%d", n)
+	groups := []string{}
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+	return sign + strings.Join(groups, loc.Thousands)
+}
+
+// FormatPercent formats an integer percentage, e.g., "45%" or "45 %"
+func (loc *Locale) FormatPercent(pct int) string {
+	return fmt.Sprintf(loc.Percent, loc.FormatInt(pct))
+}
+
+// FormatFloat formats a number with the given number of decimal places,
+// e.g., "1,234.5" or "1.234,5"
+func (loc *Locale) FormatFloat(x float64, precision int) string {
+	s := strconv.FormatFloat(x, 'f', precision, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	n, _ := strconv.Atoi(whole)
+	s = loc.FormatInt(n)
+	if strings.HasPrefix(whole, "-") && n == 0 {
+		s = "-" + s
+	}
+	if frac != "" {
+		s += loc.Decimal + frac
+	}
+	return s
+}
+
+// FormatStatsPercent formats the winning percentage of a game with
+// PercentPrecision decimal places
+func (loc *Locale) FormatStatsPercent(ps *model.Statistics) string {
+	if PercentPrecision <= 0 {
+		return loc.FormatPercent(ps.Percentage())
+	}
+	return fmt.Sprintf(loc.Percent, loc.FormatFloat(ps.ExactPercentage(), PercentPrecision))
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// LookupLocale returns the locale for a language name such as "de",
+// "de_DE", or "de_DE.UTF-8". Unsupported languages get English.
+func LookupLocale(name string) *Locale {
+	name = strings.ToLower(name)
+	if i := strings.IndexAny(name, "_.@-"); i >= 0 {
+		name = name[:i]
+	}
+	if loc, ok := Locales[name]; ok {
+		return loc
+	}
+	return Locales["en"]
+}
+
+// EnvLocale returns the locale selected by the environment, checking
+// LC_ALL, LC_MESSAGES, and LANG in that order.
+func EnvLocale() *Locale {
+	for _, envVar := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(envVar); value != "" {
+			return LookupLocale(value)
+		}
+	}
+	return Locales["en"]
+}
+
+// T translates a message using the current locale
+func T(msg string) string {
+	return CurrentLocale.T(msg)
+}
+
+// TN translates a singular or plural message using the current locale
+func TN(n int, singular, plural string) string {
+	return CurrentLocale.TN(n, singular, plural)
+}
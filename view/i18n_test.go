@@ -0,0 +1,113 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"de", "de"},
+		{"de_DE.UTF-8", "de"},
+		{"fr_CA", "fr"},
+		{"ES", "es"},
+		{"C", "en"},
+		{"", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LookupLocale(tt.name).Name)
+		})
+	}
+}
+
+func TestEnvLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "fr_FR.UTF-8")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	assert.Equal(t, "fr", EnvLocale().Name)
+}
+
+func TestLocale_FormatInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		n        int
+		expected string
+	}{
+		{"small", "en", 45, "45"},
+		{"exact group", "en", 100, "100"},
+		{"thousands", "en", 1234, "1,234"},
+		{"millions", "en", 1234567, "1,234,567"},
+		{"negative", "en", -1234, "-1,234"},
+		{"german", "de", 1234, "1.234"},
+		{"french", "fr", 1234, "1\u202f234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Locales[tt.lang].FormatInt(tt.n))
+		})
+	}
+}
+
+func TestLocale_FormatPercent(t *testing.T) {
+	assert.Equal(t, "45%", Locales["en"].FormatPercent(45))
+	assert.Equal(t, "45\u00a0%", Locales["de"].FormatPercent(45))
+}
+
+func TestLocale_FormatFloat(t *testing.T) {
+	tests := []struct {
+		name      string
+		lang      string
+		x         float64
+		precision int
+		expected  string
+	}{
+		{"no decimals", "en", 83.7, 0, "84"},
+		{"one decimal", "en", 83.73, 1, "83.7"},
+		{"grouped", "en", 1234.5, 1, "1,234.5"},
+		{"german", "de", 1234.56, 2, "1.234,56"},
+		{"negative", "fr", -0.25, 1, "-0,2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Locales[tt.lang].FormatFloat(tt.x, tt.precision))
+		})
+	}
+}
+
+func TestLocale_FormatStatsPercent(t *testing.T) {
+	ps := model.NewStatistics(175, 209, 0, 0)
+	assert.Equal(t, "84%", Locales["en"].FormatStatsPercent(ps))
+	PercentPrecision = 2
+	defer func() { PercentPrecision = 0 }()
+	assert.Equal(t, "83.73%", Locales["en"].FormatStatsPercent(ps))
+	assert.Equal(t, "83,73\u00a0%", Locales["de"].FormatStatsPercent(ps))
+}
+
+func TestLocale_T(t *testing.T) {
+	assert.Equal(t, "Beste Zeit:", Locales["de"].T("Best time:"))
+	assert.Equal(t, "Best time:", Locales["en"].T("Best time:"))
+	assert.Equal(t, "Not translated", Locales["de"].T("Not translated"))
+}
+
+func TestLocale_TN(t *testing.T) {
+	const singular, plural = "The statistics of %s game are valid", "The statistics of %s games are valid"
+	assert.Equal(t, singular, Locales["en"].TN(1, singular, plural))
+	assert.Equal(t, plural, Locales["en"].TN(0, singular, plural))
+	assert.Equal(t, "Die Statistiken von %s Spielen sind gültig", Locales["de"].TN(2, singular, plural))
+	assert.Equal(t, "Die Statistik von %s Spiel ist gültig", Locales["de"].TN(1, singular, plural))
+}
+
+func TestLocale_FormatDateTime(t *testing.T) {
+	tm := time.Date(2024, 3, 1, 20, 15, 0, 0, time.Local)
+	assert.Equal(t, "2024-03-01 20:15:00", Locales["en"].FormatDateTime(tm))
+	assert.Equal(t, "01.03.2024 20:15:00", Locales["de"].FormatDateTime(tm))
+	assert.Equal(t, "01/03/2024 20:15:00", Locales["fr"].FormatDateTime(tm))
+}
@@ -0,0 +1,67 @@
+package view
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintLeaderboard writes the overall ranking and the ranking for each
+// of the specified games, or for every game if none are specified. The
+// overall ranking is shown only when no games are specified.
+func PrintLeaderboard(w io.Writer, lb *model.Leaderboard, gameNames ...string) {
+	sNames := lb.Sections()
+	if len(gameNames) > 0 {
+		sNames = []string{}
+		for _, gameName := range gameNames {
+			sNames = append(sNames, model.ToSectionName(model.ToDisplayName(gameName)))
+		}
+	}
+	printed := false
+	printRanking := func(title string, standings []*model.Standing) {
+		if len(standings) == 0 {
+			return
+		}
+		if printed {
+			fmt.Fprintln(w)
+		}
+		printed = true
+		fmt.Fprintln(w, colorize(title, ansiBold))
+		printStandings(w, standings)
+	}
+	if len(gameNames) == 0 {
+		printRanking(fmt.Sprintf(T("All games (at least %s played)"), CurrentLocale.FormatInt(lb.MinTotal)), lb.Overall)
+	}
+	for _, sName := range sNames {
+		name := model.ToDisplayName(sName)
+		printRanking(fmt.Sprintf(T("%s (at least %s played)"), name, CurrentLocale.FormatInt(lb.MinGames)), lb.Games[sName])
+	}
+	if !printed {
+		fmt.Fprintln(w, T("No players have played enough games"))
+	}
+}
+
+// printStandings writes one ranking as a table
+func printStandings(w io.Writer, standings []*model.Standing) {
+	loc := CurrentLocale
+	header := []string{T("Player"), T("Played"), T("Wins"), T("Win %"), T("Best")}
+	rows := [][]string{}
+	for _, standing := range standings {
+		ps := standing.Stats
+		rows = append(rows, []string{
+			fmt.Sprintf("%d. %s", standing.Rank, standing.Player),
+			loc.FormatInt(ps.Total()),
+			loc.FormatInt(ps.Wins()),
+			loc.FormatStatsPercent(ps),
+			statTime(ps, ps.Best()),
+		})
+	}
+	const pctColumn = 3
+	writeTable(w, header, rows, func(row, col int, cell string) string {
+		if col == pctColumn {
+			return colorPercent(cell, standings[row].Stats.Percentage())
+		}
+		return cell
+	})
+}
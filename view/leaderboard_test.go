@@ -0,0 +1,61 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintLeaderboard(t *testing.T) {
+	share := func(player string, stats map[string]string) *model.Share {
+		snapshot := &model.Snapshot{Time: time.Now(), Stats: map[string]*model.Statistics{}}
+		for sName, statString := range stats {
+			snapshot.Stats[sName], _ = model.NewStatisticsFromString(statString)
+		}
+		return model.NewShare(player, snapshot)
+	}
+	lb := model.NewLeaderboard([]*model.Share{
+		share("alice", map[string]string{"freecell.scm": "8;10;60;90;", "spider.scm": "1;20;400;400;"}),
+		share("bob", map[string]string{"freecell.scm": "9;10;70;90;", "klondike.scm": "0;5;0;0;"}),
+	}, 10, 15)
+	tests := []struct {
+		name      string
+		gameNames []string
+		want      []string
+	}{
+		{"all", nil, []string{
+			"All games (at least 15 played)",
+			"Player    Played  Wins  Win %   Best",
+			"1. bob        15     9    60%  01:10",
+			"2. alice      30     9    30%  01:00",
+			"",
+			"Freecell (at least 10 played)",
+			"Player    Played  Wins  Win %   Best",
+			"1. bob        10     9    90%  01:10",
+			"2. alice      10     8    80%  01:00",
+			"",
+			"Spider (at least 10 played)",
+			"Player    Played  Wins  Win %   Best",
+			"1. alice      20     1     5%  06:40",
+		}},
+		{"one game", []string{"spider"}, []string{
+			"Spider (at least 10 played)",
+			"Player    Played  Wins  Win %   Best",
+			"1. alice      20     1     5%  06:40",
+		}},
+		{"nobody", []string{"klondike"}, []string{
+			"No players have played enough games",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintLeaderboard(&buf, lb, tt.gameNames...)
+			assert.Equal(t, tt.want, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"))
+		})
+	}
+}
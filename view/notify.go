@@ -0,0 +1,128 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// Notifier shows a desktop notification
+type Notifier interface {
+	Notify(n *Notification) error
+}
+
+// Notification is a message for the player
+type Notification struct {
+	Summary string // Title of the notification
+	Body    string
+}
+
+// NotifySend sends notifications with the notify-send program
+type NotifySend struct{}
+
+// DBusNotifier sends notifications through the freedesktop.org
+// notifications D-Bus interface, using the gdbus program
+type DBusNotifier struct{}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// NotifyMethods are the names accepted by NewNotifier
+var NotifyMethods = []string{"auto", "notify-send", "dbus", "none"}
+
+// runCommand runs a program. It is a variable so that it can be
+// replaced in tests.
+var runCommand = func(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
+}
+
+// ---------------------------------------------------------------------
+// Constructor
+// ---------------------------------------------------------------------
+
+// NewNotifier returns the notifier for a method in NotifyMethods. For
+// "none" it returns nil. For "auto" it returns the first one that is
+// installed.
+func NewNotifier(method string) (Notifier, error) {
+	switch method {
+	case "none":
+		return nil, nil
+	case "notify-send":
+		return NotifySend{}, nil
+	case "dbus":
+		return DBusNotifier{}, nil
+	case "auto":
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			return NotifySend{}, nil
+		}
+		if _, err := exec.LookPath("gdbus"); err == nil {
+			return DBusNotifier{}, nil
+		}
+		return nil, errors.New("neither notify-send nor gdbus is installed")
+	default:
+		return nil, fmt.Errorf("invalid notification method %q", method)
+	}
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Notify runs notify-send
+func (NotifySend) Notify(n *Notification) error {
+	return runCommand("notify-send", "--app-name=arstats", "--", n.Summary, n.Body)
+}
+
+// Notify calls org.freedesktop.Notifications.Notify
+func (DBusNotifier) Notify(n *Notification) error {
+	return runCommand("gdbus", "call", "--session",
+		"--dest", "org.freedesktop.Notifications",
+		"--object-path", "/org/freedesktop/Notifications",
+		"--method", "org.freedesktop.Notifications.Notify",
+		"arstats", "0", "", n.Summary, n.Body, "[]", "{}", "-1")
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// Notifications returns the messages for milestones and for newly
+// unlocked achievements
+func Notifications(milestones []*model.Milestone, unlocked []*model.Rule) []*Notification {
+	loc := CurrentLocale
+	notifications := []*Notification{}
+	for _, m := range milestones {
+		name := model.ToDisplayName(m.Section)
+		var n *Notification
+		switch m.Kind {
+		case model.MilestoneBestTime:
+			n = &Notification{T("New best time"), fmt.Sprintf("%s: %s", name, SecondsToTime(m.After.Best()))}
+			if m.Before.HasTimes() {
+				n.Body += fmt.Sprintf(T(" (was %s)"), SecondsToTime(m.Before.Best()))
+			}
+		case model.MilestonePercentUp:
+			n = &Notification{T("Winning percentage up"), fmt.Sprintf("%s: %s", name, loc.FormatStatsPercent(m.After))}
+			n.Body += fmt.Sprintf(T(" (was %s)"), loc.FormatStatsPercent(m.Before))
+		case model.MilestoneGamesTotal:
+			n = &Notification{T("Milestone"), fmt.Sprintf(T("%s: %s games played"), name, loc.FormatInt(m.After.Total()))}
+		default:
+			continue
+		}
+		notifications = append(notifications, n)
+	}
+	for _, rule := range unlocked {
+		body := T(rule.Name)
+		if rule.Description != "" {
+			body += ": " + T(rule.Description)
+		}
+		notifications = append(notifications, &Notification{T("Achievement unlocked"), body})
+	}
+	return notifications
+}
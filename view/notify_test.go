@@ -0,0 +1,74 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifications(t *testing.T) {
+	stats := func(s string) *model.Statistics {
+		ps, _ := model.NewStatisticsFromString(s)
+		return ps
+	}
+	milestones := []*model.Milestone{
+		{Kind: model.MilestoneBestTime, Section: "freecell", Before: stats("5;10;88;90;"), After: stats("6;11;80;90;")},
+		{Kind: model.MilestoneBestTime, Section: "spider", Before: stats("0;3;0;0;"), After: stats("1;4;200;200;")},
+		{Kind: model.MilestoneGamesTotal, Section: "klondike", Before: stats("50;99;60;90;"), After: stats("50;100;60;90;")},
+	}
+	unlocked := []*model.Rule{
+		{Name: "First win", Description: "Win a game"},
+		{Name: "Quick"},
+	}
+	assert.Equal(t, []*Notification{
+		{"New best time", "Freecell: 01:20 (was 01:28)"},
+		{"New best time", "Spider: 03:20"},
+		{"Milestone", "Klondike: 100 games played"},
+		{"Achievement unlocked", "First win: Win a game"},
+		{"Achievement unlocked", "Quick"},
+	}, Notifications(milestones, unlocked))
+}
+
+func TestNewNotifier(t *testing.T) {
+	tests := []struct {
+		method string
+		want   Notifier
+		err    bool
+	}{
+		{"none", nil, false},
+		{"notify-send", NotifySend{}, false},
+		{"dbus", DBusNotifier{}, false},
+		{"bogus", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			have, err := NewNotifier(tt.method)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, have)
+		})
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	saved := runCommand
+	defer func() { runCommand = saved }()
+	var args []string
+	runCommand = func(name string, a ...string) error {
+		args = append([]string{name}, a...)
+		return nil
+	}
+	n := &Notification{"Milestone", "Klondike: 100 games played"}
+
+	assert.NoError(t, NotifySend{}.Notify(n))
+	assert.Equal(t, []string{"notify-send", "--app-name=arstats", "--", "Milestone", "Klondike: 100 games played"}, args)
+
+	assert.NoError(t, DBusNotifier{}.Notify(n))
+	assert.Equal(t, "gdbus", args[0])
+	assert.Contains(t, args, "org.freedesktop.Notifications.Notify")
+	assert.Contains(t, args, "Klondike: 100 games played")
+}
@@ -0,0 +1,149 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// ReportPeriod is one period of a report in JSON
+type ReportPeriod struct {
+	Period string        `json:"period"` // e.g., "2024-W10"
+	Start  string        `json:"start"`  // First day of the period
+	End    string        `json:"end"`    // Last day of the period
+	Games  []*ReportGame `json:"games"`
+}
+
+// ReportGame is the activity in one game in a ReportPeriod. NewBest is
+// null if no new best time was set.
+type ReportGame struct {
+	Name       string  `json:"name"`
+	Section    string  `json:"section"`
+	Played     int     `json:"played"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	Percentage int     `json:"percentage"`
+	NewBest    *string `json:"newBest"`
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// ReportFormats are the output formats accepted by PrintReport
+var ReportFormats = []string{"text", "markdown", "json"}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// NewReport groups activities by period
+func NewReport(activities []*model.Activity, period model.Period) []*ReportPeriod {
+	report := []*ReportPeriod{}
+	var rp *ReportPeriod
+	for _, pa := range activities {
+		if label := period.Label(pa.Start); rp == nil || rp.Period != label {
+			rp = &ReportPeriod{
+				Period: label,
+				Start:  pa.Start.Format("2006-01-02"),
+				End:    pa.End.AddDate(0, 0, -1).Format("2006-01-02"),
+				Games:  []*ReportGame{},
+			}
+			report = append(report, rp)
+		}
+		game := &ReportGame{
+			Name:       model.ToDisplayName(pa.Section),
+			Section:    pa.Section,
+			Played:     pa.Played(),
+			Wins:       pa.Wins,
+			Losses:     pa.Losses,
+			Percentage: pa.Statistics().Percentage(),
+		}
+		if pa.NewBest > 0 {
+			game.NewBest = exportTime(pa.NewBest)
+		}
+		rp.Games = append(rp.Games, game)
+	}
+	return report
+}
+
+// PrintReport writes the activities grouped by period as text,
+// Markdown, or JSON.
+func PrintReport(w io.Writer, activities []*model.Activity, period model.Period, format string) error {
+	report := NewReport(activities, period)
+	switch format {
+	case "json":
+		return writeJSON(w, report)
+	case "text", "markdown":
+	default:
+		return fmt.Errorf("invalid report format %q", format)
+	}
+	if len(report) == 0 {
+		fmt.Fprintln(w, T("No games were played in this time"))
+		return nil
+	}
+
+	header := []string{
+		T("Game"), T("Played"), T("Wins"), T("Losses"), T("Win %"), T("New best"),
+	}
+	for i, rp := range report {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		rows := [][]string{}
+		for _, game := range rp.Games {
+			newBest := ""
+			if game.NewBest != nil {
+				newBest = *game.NewBest
+			}
+			ps := model.NewStatistics(game.Wins, game.Played, 0, 0)
+			rows = append(rows, []string{
+				game.Name,
+				CurrentLocale.FormatInt(game.Played),
+				CurrentLocale.FormatInt(game.Wins),
+				CurrentLocale.FormatInt(game.Losses),
+				CurrentLocale.FormatStatsPercent(ps),
+				newBest,
+			})
+		}
+		title := rp.Period
+		if rp.Start != rp.End && period != model.PeriodDay {
+			title += fmt.Sprintf(" (%s – %s)", rp.Start, rp.End)
+		}
+		if format == "markdown" {
+			fmt.Fprintf(w, "## %s\n\n", title)
+			writeMarkdownTable(w, header, rows)
+		} else {
+			fmt.Fprintln(w, title)
+			writeTable(w, header, rows, nil)
+		}
+	}
+	return nil
+}
+
+// writeMarkdownTable writes a header and rows as a Markdown table, with
+// the first column left-aligned and the others right-aligned
+func writeMarkdownTable(w io.Writer, header []string, rows [][]string) {
+	escape := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |"
+	}
+	fmt.Fprintln(w, escape(header))
+	align := []string{":---"}
+	for range header[1:] {
+		align = append(align, "---:")
+	}
+	fmt.Fprintln(w, "| "+strings.Join(align, " | ")+" |")
+	for _, row := range rows {
+		fmt.Fprintln(w, escape(row))
+	}
+}
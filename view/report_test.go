@@ -0,0 +1,72 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestActivities() []*model.Activity {
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	return []*model.Activity{
+		{Start: start, End: start.AddDate(0, 0, 7), Section: "freecell.scm", Wins: 3, Losses: 1, NewBest: 75},
+		{Start: start, End: start.AddDate(0, 0, 7), Section: "spider.scm", Losses: 2},
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	tests := []struct {
+		name     string
+		format   string
+		expected []string
+	}{
+		{"text", "text", []string{
+			"2024-W10 (2024-03-04 – 2024-03-10)",
+			"Game      Played  Wins  Losses  Win %  New best",
+			"Freecell       4     3       1    75%     01:15",
+			"Spider         2     0       2     0%",
+		}},
+		{"markdown", "markdown", []string{
+			"## 2024-W10 (2024-03-04 – 2024-03-10)",
+			"",
+			"| Game | Played | Wins | Losses | Win % | New best |",
+			"| :--- | ---: | ---: | ---: | ---: | ---: |",
+			"| Freecell | 4 | 3 | 1 | 75% | 01:15 |",
+			"| Spider | 2 | 0 | 2 | 0% |  |",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintReport(&buf, newTestActivities(), model.PeriodWeek, tt.format)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"))
+		})
+	}
+}
+
+func TestPrintReportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintReport(&buf, newTestActivities(), model.PeriodWeek, "json")
+	assert.Nil(t, err)
+	var report []*ReportPeriod
+	err = json.Unmarshal(buf.Bytes(), &report)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(report))
+	assert.Equal(t, "2024-03-10", report[0].End)
+	assert.Equal(t, "01:15", *report[0].Games[0].NewBest)
+	assert.Nil(t, report[0].Games[1].NewBest)
+}
+
+func TestPrintReportEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, PrintReport(&buf, nil, model.PeriodDay, "text"))
+	assert.Equal(t, "No games were played in this time\n", buf.String())
+	assert.NotNil(t, PrintReport(&buf, nil, model.PeriodDay, "html"))
+}
@@ -0,0 +1,61 @@
+package view
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintStreaks writes the current and longest streaks for each of the
+// specified games, or for every game in the history if none are
+// specified.
+func PrintStreaks(w io.Writer, history *model.History, gameNames ...string) {
+	if len(history.Snapshots) == 0 {
+		fmt.Fprintln(w, T("No history has been recorded"))
+		return
+	}
+	header := []string{
+		T("Game"), T("Current streak"), T("Longest winning streak"), T("Longest losing streak"),
+	}
+	sNames := history.Sections()
+	if len(gameNames) > 0 {
+		sNames = []string{}
+		for _, gameName := range gameNames {
+			sNames = append(sNames, model.ToSectionName(model.ToDisplayName(gameName)))
+		}
+	}
+	rows := [][]string{}
+	atLeast := false
+	for _, sName := range sNames {
+		st := model.NewStreaks(history.Series(sName))
+		current := "?"
+		if st.CurrentKnown {
+			current = formatStreak(st.Current, st.CurrentIsWin)
+		}
+		rows = append(rows, []string{
+			model.ToDisplayName(sName),
+			current,
+			formatStreak(st.LongestWins, true),
+			formatStreak(st.LongestLosses, false),
+		})
+		atLeast = atLeast || st.Current.AtLeast || st.LongestWins.AtLeast || st.LongestLosses.AtLeast
+	}
+	writeTable(w, header, rows, nil)
+	if atLeast {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, T("≥ means at least, because several games were played between snapshots"))
+	}
+}
+
+// formatStreak formats a streak, e.g., "3 won" or "≥2 lost"
+func formatStreak(streak model.Streak, win bool) string {
+	n := CurrentLocale.FormatInt(streak.Length)
+	if streak.AtLeast {
+		n = "≥" + n
+	}
+	if win {
+		return fmt.Sprintf(T("%s won"), n)
+	}
+	return fmt.Sprintf(T("%s lost"), n)
+}
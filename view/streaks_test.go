@@ -0,0 +1,35 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintStreaks(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	history := &model.History{}
+	t0 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, statString := range []string{"0;0;0;0;", "1;1;60;60;", "2;2;60;70;", "3;5;60;80;"} {
+		ps, err := model.NewStatisticsFromString(statString)
+		assert.Nil(t, err)
+		history.Add(&model.Snapshot{
+			Time:  t0.Add(time.Duration(i) * time.Hour),
+			Stats: map[string]*model.Statistics{"freecell.scm": ps},
+		})
+	}
+	var buf bytes.Buffer
+	PrintStreaks(&buf, history)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, "Freecell               ?                  ≥2 won                ≥1 lost", lines[1])
+	assert.Contains(t, lines[3], "≥ means at least")
+
+	buf.Reset()
+	PrintStreaks(&buf, &model.History{})
+	assert.Equal(t, "No history has been recorded\n", buf.String())
+}
@@ -0,0 +1,119 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintTable writes one row of statistics for each of the specified
+// games, or for every game that has them if none are specified. The
+// game name column is truncated if the table would be wider than the
+// terminal.
+func PrintTable(w io.Writer, pdp *model.DataProvider, gameNames ...string) error {
+	loc := CurrentLocale
+	header := []string{
+		T("Game"), T("Played"), T("Wins"), T("Losses"), T("Win %"),
+		T("Best"), T("Average"), T("Worst"),
+	}
+	rows := [][]string{}
+	pcts := []int{}
+	for _, sName := range gameSections(pdp, gameNames) {
+		td, err := NewTemplateData(pdp, model.ToDisplayName(sName))
+		if err != nil {
+			return err
+		}
+		ps := td.Stats
+		rows = append(rows, []string{
+			td.Name,
+			loc.FormatInt(ps.Total()),
+			loc.FormatInt(ps.Wins()),
+			loc.FormatInt(ps.Losses()),
+			loc.FormatStatsPercent(ps),
+			statTime(ps, ps.Best()),
+			statTime(ps, ps.Average()),
+			statTime(ps, ps.Worst()),
+		})
+		pcts = append(pcts, ps.Percentage())
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, T("No games have been played"))
+		return nil
+	}
+
+	const pctColumn = 4
+	writeTable(w, header, rows, func(row, col int, cell string) string {
+		if col == pctColumn {
+			return colorPercent(cell, pcts[row])
+		}
+		return cell
+	})
+	return nil
+}
+
+// writeTable writes a header and rows with the first column left-aligned
+// and the others right-aligned. The first column is truncated if the
+// table would be wider than the terminal. If style is not nil, it is
+// called to decorate each cell in the rows after padding.
+func writeTable(w io.Writer, header []string, rows [][]string, style func(row, col int, cell string) string) {
+
+	// Find the width of each column
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if n := DisplayWidth(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	// Narrow the name column if the table does not fit
+	if termWidth := TerminalWidth(); termWidth > 0 {
+		total := 2 * (len(widths) - 1)
+		for _, n := range widths {
+			total += n
+		}
+		if excess := total - termWidth; excess > 0 {
+			widths[0] -= excess
+			if widths[0] < 4 {
+				widths[0] = 4
+			}
+		}
+	}
+
+	// Write the rows. The header is not styled.
+	writeRow := func(row []string, r int) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if i == 0 {
+				cell = PadRight(Truncate(cell, widths[i]), widths[i])
+			} else {
+				cell = strings.Repeat(" ", widths[i]-DisplayWidth(cell)) + cell
+			}
+			if r >= 0 && style != nil {
+				cell = style(r, i, cell)
+			}
+			cells[i] = cell
+		}
+		fmt.Fprintln(w, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+	writeRow(header, -1)
+	for r, row := range rows {
+		writeRow(row, r)
+	}
+}
+
+// gameSections returns the section names of the specified games, or of
+// every game with statistics if none are specified
+func gameSections(pdp *model.DataProvider, gameNames []string) []string {
+	if len(gameNames) == 0 {
+		return pdp.StatsSections()
+	}
+	sNames := []string{}
+	for _, gameName := range gameNames {
+		sNames = append(sNames, model.ToSectionName(model.ToDisplayName(gameName)))
+	}
+	return sNames
+}
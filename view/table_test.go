@@ -0,0 +1,54 @@
+package view
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintTable(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	err = PrintTable(&buf, pdp)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, "Game      Played  Wins  Losses  Win %   Best  Average  Worst", lines[0])
+	assert.Equal(t, "Freecell     209   175      34    84%  01:28    04:07  06:46", lines[2])
+	assert.Equal(t, "Klondike       1     0       1     0%    N/A      N/A    N/A", lines[3])
+}
+
+func TestPrintTableNarrow(t *testing.T) {
+	t.Setenv("COLUMNS", "56")
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	err = PrintTable(&buf, pdp)
+	assert.Nil(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		assert.LessOrEqual(t, DisplayWidth(line), 56, line)
+	}
+	assert.Contains(t, buf.String(), "Fre…")
+}
+
+func TestPrintTableGames(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	err = PrintTable(&buf, pdp, "klondike", "Freecell")
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.True(t, strings.HasPrefix(lines[1], "Klondike"))
+	assert.True(t, strings.HasPrefix(lines[2], "Freecell"))
+
+	err = PrintTable(&buf, pdp, "bogus")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,119 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// TemplateData is the object passed to a user-supplied template. The
+// statistics are available through the methods of *model.Statistics,
+// e.g., {{.Stats.Wins}} or {{.Stats.Percentage}}.
+type TemplateData struct {
+	Name     string            // Display name in the current locale
+	Section  string            // Section name, e.g., "spider.scm"
+	Options  string            // "Options" value from the section, if any
+	HasStats bool              // False for a game in the Recent list with no statistics
+	Stats    *model.Statistics // Parsed statistics, zero if HasStats is false
+	History  []model.Sample    // Statistics in each snapshot, oldest first
+}
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+// TemplateFuncs are the helper functions available to templates.
+// Both duration and time use the --time-format, but time also takes the
+// statistics and gives "N/A" for a game that has never been won, e.g.,
+// {{time .Stats .Stats.Best}}.
+var TemplateFuncs = template.FuncMap{
+	"duration": SecondsToTime,
+	"number":   number,
+	"percent":  percent,
+	"plural":   plural,
+	"time":     statTime,
+	"tr":       T,
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// NewTemplate parses the template text and returns the template with
+// the helper functions installed.
+func NewTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateFuncs).Parse(text)
+}
+
+// NewTemplateFromFile reads a template from the specified file.
+func NewTemplateFromFile(filename string) (*template.Template, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewTemplate(filename, string(data))
+}
+
+// NewTemplateData looks up the specified game and returns the data
+// that will be passed to a template.
+func NewTemplateData(pdp *model.DataProvider, gameName string) (*TemplateData, error) {
+	game, err := pdp.Game(gameName)
+	if err != nil {
+		return nil, err
+	}
+	td := &TemplateData{
+		Name:     game.Name,
+		Section:  game.Section,
+		Options:  game.Options,
+		HasStats: game.HasStats,
+		Stats:    game.Stats,
+	}
+	return td, nil
+}
+
+// PrintTemplate writes the statistics for the specified game using the
+// template. The history, if not nil, gives the template the game's
+// statistics in each snapshot.
+func PrintTemplate(w io.Writer, pdp *model.DataProvider, history *model.History, gameName string, tmpl *template.Template) error {
+	td, err := NewTemplateData(pdp, gameName)
+	if err != nil {
+		return err
+	}
+	td.History = []model.Sample{}
+	if history != nil {
+		td.History = history.Series(td.Section)
+	}
+	return tmpl.Execute(w, td)
+}
+
+// number formats an integer in the current locale
+func number(n int) string {
+	return CurrentLocale.FormatInt(n)
+}
+
+// percent formats an integer percentage in the current locale, e.g.,
+// "45%"
+func percent(pct int) string {
+	return CurrentLocale.FormatPercent(pct)
+}
+
+// plural returns the count followed by the singular or plural form of
+// the word.  If no plural form is given, "s" is appended to the
+// singular.
+func plural(n int, singular string, plurals ...string) string {
+	if n == 1 {
+		return fmt.Sprintf("%s %s", number(n), singular)
+	}
+	word := singular + "s"
+	if len(plurals) > 0 {
+		word = plurals[0]
+	}
+	return fmt.Sprintf("%s %s", number(n), word)
+}
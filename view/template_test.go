@@ -0,0 +1,106 @@
+package view
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintTemplate(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		text     string
+		gameName string
+		expected string
+	}{
+		{"name and section", "{{.Name}} [{{.Section}}]", "Spider", "Spider [spider.scm]"},
+		{"options", "{{.Options}}", "spider", "2"},
+		{"percent", "{{percent .Stats.Percentage}}", "Freecell", "84%"},
+		{"duration", "{{duration .Stats.Best}}", "Freecell", "01:28"},
+		{"time", "{{time .Stats .Stats.Best}}", "Freecell", "01:28"},
+		{"time never won", "{{time .Stats .Stats.Worst}}", "Klondike", "N/A"},
+		{"plural", "{{plural .Stats.Losses \"loss\" \"losses\"}}", "Klondike", "1 loss"},
+		{"plural default", "{{plural .Stats.Wins \"win\"}}", "Spider", "45 wins"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := NewTemplate(tt.name, tt.text)
+			assert.Nil(t, err)
+			var buf bytes.Buffer
+			err = PrintTemplate(&buf, pdp, nil, tt.gameName, tmpl)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestPrintTemplateNotFound(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	tmpl, err := NewTemplate("test", "{{.Name}}")
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	err = PrintTemplate(&buf, pdp, nil, "Bogus", tmpl)
+	assert.NotNil(t, err)
+}
+
+func TestNewTemplateDataOptions(t *testing.T) {
+	pdp, err := model.NewDataProviderFromReader(strings.NewReader(
+		"[freecell.scm]\nStatistic=1;2;60;60;\nOptions=0\n\n[spider.scm]\nStatistic=1;2;60;60;\nOptions=bogus\n"))
+	assert.Nil(t, err)
+	td, err := NewTemplateData(pdp, "Freecell")
+	assert.Nil(t, err)
+	assert.Equal(t, "0", td.Options)
+	td, err = NewTemplateData(pdp, "Spider")
+	assert.Nil(t, err)
+	assert.Equal(t, "bogus", td.Options)
+	assert.Equal(t, 2, td.Stats.Total())
+}
+
+func TestNewTemplateDataNoStats(t *testing.T) {
+	pdp, err := model.NewDataProviderFromReader(strings.NewReader(
+		"[Aisleriot Config]\nRecent=block-ten;freecell;\n\n[freecell.scm]\nStatistic=1;2;60;60;\n"))
+	assert.Nil(t, err)
+	td, err := NewTemplateData(pdp, "Block Ten")
+	assert.Nil(t, err)
+	assert.Equal(t, "block_ten.scm", td.Section)
+	assert.False(t, td.HasStats)
+	assert.Equal(t, 0, td.Stats.Total())
+	td, err = NewTemplateData(pdp, "Freecell")
+	assert.Nil(t, err)
+	assert.True(t, td.HasStats)
+	_, err = NewTemplateData(pdp, "Yukon")
+	assert.NotNil(t, err)
+}
+
+func TestPrintTemplateHistory(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	history := &model.History{}
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, statString := range []string{"170;200;88;406;", "175;209;88;406;"} {
+		ps, err := model.NewStatisticsFromString(statString)
+		assert.Nil(t, err)
+		history.Add(&model.Snapshot{
+			Time:  t0.AddDate(0, 0, i),
+			Stats: map[string]*model.Statistics{"freecell.scm": ps},
+		})
+	}
+	tmpl, err := NewTemplate("history", `{{range .History}}{{.Time.Format "01-02"}} {{.Stats.Total}};{{end}}`)
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	assert.Nil(t, PrintTemplate(&buf, pdp, history, "Freecell", tmpl))
+	assert.Equal(t, "03-01 200;03-02 209;", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, PrintTemplate(&buf, pdp, nil, "Freecell", tmpl))
+	assert.Equal(t, "", buf.String())
+}
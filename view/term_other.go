@@ -0,0 +1,25 @@
+//go:build !linux
+
+package view
+
+import (
+	"errors"
+	"os"
+)
+
+// terminalWidth is not supported on this platform, so it always returns
+// zero.
+func terminalWidth(f *os.File) int {
+	return 0
+}
+
+// terminalSize is not supported on this platform, so it always returns
+// zeros.
+func terminalSize(f *os.File) (int, int) {
+	return 0, 0
+}
+
+// makeRaw is not supported on this platform.
+func makeRaw(f *os.File) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
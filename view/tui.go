@@ -0,0 +1,473 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// ---------------------------------------------------------------------
+// Type Definitions
+// ---------------------------------------------------------------------
+
+// TUI is the state of the full-screen game browser
+type TUI struct {
+	filename  string      // The keyfile being browsed
+	modTime   time.Time   // Modification time when it was last read
+	games     []*tuiGame  // All games in the keyfile
+	visible   []*tuiGame  // Games matching the search, in sorted order
+	selected  int         // Index of the selected game in visible
+	offset    int         // Index of the first game shown in the list
+	sortBy    tuiSortMode // Current sort order
+	reverse   bool        // True if the sort order is reversed
+	query     string      // Search text
+	searching bool        // True while the search box has the focus
+	message   string      // Error to show in the status line
+}
+
+// tuiGame is one game in the browser list
+type tuiGame struct {
+	td  *TemplateData
+	err error
+}
+
+// tuiSortMode is the order of the list
+type tuiSortMode int
+
+// ---------------------------------------------------------------------
+// Constants
+// ---------------------------------------------------------------------
+
+const (
+	tuiSortName tuiSortMode = iota
+	tuiSortPlayed
+	tuiSortWins
+	tuiSortPercentage
+	tuiSortModes // Number of sort modes
+)
+
+// Keys returned by parseKeys that are not single characters
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdn"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyInterrupt = "ctrl-c"
+)
+
+// ANSI sequences used by the browser
+const (
+	ansiReverse    = "\x1b[7m"
+	ansiHome       = "\x1b[H"
+	ansiClearLine  = "\x1b[K"
+	ansiAltScreen  = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+)
+
+// tuiPollInterval is how often the keyfile is checked for changes
+const tuiPollInterval = time.Second
+
+var tuiSortNames = map[tuiSortMode]string{
+	tuiSortName:       "name",
+	tuiSortPlayed:     "played",
+	tuiSortWins:       "wins",
+	tuiSortPercentage: "percentage",
+}
+
+// ---------------------------------------------------------------------
+// Constructor
+// ---------------------------------------------------------------------
+
+// NewTUI creates a browser for the games in the specified keyfile
+func NewTUI(filename string) (*TUI, error) {
+	t := &TUI{filename: filename}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ---------------------------------------------------------------------
+// Methods
+// ---------------------------------------------------------------------
+
+// Run takes over the terminal and runs the browser until the user
+// quits. The keyfile is reread whenever it changes.
+func (t *TUI) Run() error {
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	fmt.Print(ansiAltScreen + ansiHideCursor)
+	defer fmt.Print(ansiShowCursor + ansiMainScreen)
+
+	// Read keys in the background so that the file can be polled
+	input := make(chan []byte)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(input)
+				return
+			}
+			input <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+	ticker := time.NewTicker(tuiPollInterval)
+	defer ticker.Stop()
+
+	for {
+		width, height := terminalSize(os.Stdout)
+		t.Draw(os.Stdout, width, height)
+		select {
+		case data, ok := <-input:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseKeys(data) {
+				if t.HandleKey(key, height) {
+					return nil
+				}
+			}
+		case <-ticker.C:
+			t.refresh()
+		}
+	}
+}
+
+// Draw writes the whole screen
+func (t *TUI) Draw(w io.Writer, width, height int) {
+	var sb strings.Builder
+	sb.WriteString(ansiHome)
+	for _, line := range t.Render(width, height) {
+		sb.WriteString(line)
+		sb.WriteString(ansiClearLine)
+		sb.WriteString("\r\n")
+	}
+	io.WriteString(w, strings.TrimSuffix(sb.String(), "\r\n"))
+}
+
+// Render returns the lines of the screen for a terminal of the
+// specified size: a header, the game list beside the details of the
+// selected game, and a status line.
+func (t *TUI) Render(width, height int) []string {
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 24
+	}
+	lines := make([]string, 0, height)
+
+	// Header
+	direction := "↑"
+	if t.reverse {
+		direction = "↓"
+	}
+	header := fmt.Sprintf(" arstats: %d games  sort: %s %s  search: %s",
+		len(t.visible), tuiSortNames[t.sortBy], direction, t.query)
+	if t.searching {
+		header += "_"
+	}
+	lines = append(lines, ansiReverse+PadRight(Truncate(header, width), width)+ansiReset)
+
+	// Game list and details
+	listWidth := width / 3
+	if listWidth > 30 {
+		listWidth = 30
+	}
+	bodyHeight := t.bodyHeight(height)
+	t.scroll(bodyHeight)
+	var details []string
+	if t.selected < len(t.visible) {
+		game := t.visible[t.selected]
+		if game.err != nil {
+			details = []string{game.td.Name, game.err.Error()}
+		} else {
+			details = FormatStatistics(game.td, width-listWidth-3)
+		}
+	}
+	for i := 0; i < bodyHeight; i++ {
+		item := ""
+		if j := t.offset + i; j < len(t.visible) {
+			item = PadRight(Truncate(" "+t.visible[j].td.Name, listWidth), listWidth)
+			if j == t.selected {
+				item = ansiReverse + item + ansiReset
+			}
+		} else {
+			item = PadRight("", listWidth)
+		}
+		detail := ""
+		if i < len(details) {
+			detail = details[i]
+		}
+		lines = append(lines, item+" │ "+detail)
+	}
+
+	// Status line
+	status := " ↑/↓ move  s sort  r reverse  / search  q quit"
+	if t.searching {
+		status = " type to search  enter done  esc clear"
+	}
+	if t.message != "" {
+		status = " " + t.message
+	}
+	lines = append(lines, Truncate(status, width))
+	return lines
+}
+
+// HandleKey updates the state for a key press, and returns true if the
+// user wants to quit.
+func (t *TUI) HandleKey(key string, height int) bool {
+	if key == keyInterrupt {
+		return true
+	}
+	if t.searching {
+		switch key {
+		case keyEnter:
+			t.searching = false
+		case keyEscape:
+			t.searching = false
+			t.query = ""
+		case keyBackspace:
+			if r := []rune(t.query); len(r) > 0 {
+				t.query = string(r[:len(r)-1])
+			}
+		case keyUp, keyDown, keyPageUp, keyPageDown, keyHome, keyEnd:
+			t.move(key, height)
+		default:
+			if len([]rune(key)) == 1 {
+				t.query += key
+			}
+		}
+		t.update()
+		return false
+	}
+	switch key {
+	case "q", keyEscape:
+		return true
+	case "k", keyUp, "j", keyDown, keyPageUp, keyPageDown, keyHome, keyEnd:
+		t.move(key, height)
+	case "s":
+		t.sortBy = (t.sortBy + 1) % tuiSortModes
+		t.update()
+	case "r":
+		t.reverse = !t.reverse
+		t.update()
+	case "/":
+		t.searching = true
+	}
+	return false
+}
+
+// bodyHeight returns the number of lines available for the game list
+func (t *TUI) bodyHeight(height int) int {
+	if height <= 0 {
+		height = 24
+	}
+	if height < 3 {
+		return 1
+	}
+	return height - 2
+}
+
+// move changes the selected game
+func (t *TUI) move(key string, height int) {
+	page := t.bodyHeight(height)
+	switch key {
+	case "k", keyUp:
+		t.selected--
+	case "j", keyDown:
+		t.selected++
+	case keyPageUp:
+		t.selected -= page
+	case keyPageDown:
+		t.selected += page
+	case keyHome:
+		t.selected = 0
+	case keyEnd:
+		t.selected = len(t.visible) - 1
+	}
+	if t.selected >= len(t.visible) {
+		t.selected = len(t.visible) - 1
+	}
+	if t.selected < 0 {
+		t.selected = 0
+	}
+}
+
+// scroll adjusts the list offset so that the selected game is shown
+func (t *TUI) scroll(bodyHeight int) {
+	if t.selected < t.offset {
+		t.offset = t.selected
+	}
+	if t.selected >= t.offset+bodyHeight {
+		t.offset = t.selected - bodyHeight + 1
+	}
+}
+
+// load reads the keyfile and rebuilds the game list
+func (t *TUI) load() error {
+	fi, err := os.Stat(t.filename)
+	if err != nil {
+		return err
+	}
+	pdp, err := model.NewDataProvider(t.filename)
+	if err != nil {
+		return err
+	}
+	t.modTime = fi.ModTime()
+	t.games = nil
+	for _, sName := range pdp.StatsSections() {
+		gameName := model.ToDisplayName(sName)
+		td, err := NewTemplateData(pdp, gameName)
+		if err != nil {
+			td = &TemplateData{
+				Name:    model.ToDisplayName(gameName),
+				Section: sName,
+				Stats:   model.NewStatistics(0, 0, 0, 0),
+			}
+		}
+		t.games = append(t.games, &tuiGame{td: td, err: err})
+	}
+	t.update()
+	return nil
+}
+
+// refresh rereads the keyfile if it has changed
+func (t *TUI) refresh() {
+	fi, err := os.Stat(t.filename)
+	if err != nil {
+		t.message = err.Error()
+		return
+	}
+	if fi.ModTime().Equal(t.modTime) {
+		return
+	}
+	if err := t.load(); err != nil {
+		t.message = err.Error()
+		return
+	}
+	t.message = ""
+}
+
+// update filters and sorts the list, keeping the same game selected if
+// it is still visible
+func (t *TUI) update() {
+	current := ""
+	if t.selected < len(t.visible) {
+		current = t.visible[t.selected].td.Section
+	}
+
+	// Filter by the search text, which may be given either as a display
+	// name or a section name
+	query := strings.ToLower(strings.TrimSpace(t.query))
+	sQuery := strings.TrimSuffix(model.ToSectionName(query), ".scm")
+	t.visible = nil
+	for _, game := range t.games {
+		name := strings.ToLower(game.td.Name)
+		if query == "" ||
+			strings.Contains(name, query) ||
+			strings.Contains(game.td.Section, sQuery) {
+			t.visible = append(t.visible, game)
+		}
+	}
+
+	// Sort, using the name to break ties
+	sort.SliceStable(t.visible, func(i, j int) bool {
+		a, b := t.visible[i], t.visible[j]
+		if t.reverse {
+			a, b = b, a
+		}
+		var ka, kb int
+		switch t.sortBy {
+		case tuiSortPlayed:
+			ka, kb = a.td.Stats.Total(), b.td.Stats.Total()
+		case tuiSortWins:
+			ka, kb = a.td.Stats.Wins(), b.td.Stats.Wins()
+		case tuiSortPercentage:
+			ka, kb = a.td.Stats.Percentage(), b.td.Stats.Percentage()
+		}
+		if ka != kb {
+			return ka < kb
+		}
+		return a.td.Name < b.td.Name
+	})
+
+	// Restore the selection
+	t.selected = 0
+	for i, game := range t.visible {
+		if game.td.Section == current {
+			t.selected = i
+			break
+		}
+	}
+}
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// parseKeys splits raw terminal input into key names. Printable
+// characters are returned as themselves.
+func parseKeys(data []byte) []string {
+	sequences := map[string]string{
+		"\x1b[A":  keyUp,
+		"\x1bOA":  keyUp,
+		"\x1b[B":  keyDown,
+		"\x1bOB":  keyDown,
+		"\x1b[5~": keyPageUp,
+		"\x1b[6~": keyPageDown,
+		"\x1b[H":  keyHome,
+		"\x1b[1~": keyHome,
+		"\x1b[F":  keyEnd,
+		"\x1b[4~": keyEnd,
+	}
+	keys := []string{}
+	s := string(data)
+	for len(s) > 0 {
+		matched := false
+		for seq, key := range sequences {
+			if strings.HasPrefix(s, seq) {
+				keys = append(keys, key)
+				s = s[len(seq):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		r := []rune(s)[0]
+		s = s[len(string(r)):]
+		switch r {
+		case '\x1b':
+			keys = append(keys, keyEscape)
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case '\x7f', '\b':
+			keys = append(keys, keyBackspace)
+		case '\x03':
+			keys = append(keys, keyInterrupt)
+		default:
+			if r >= ' ' {
+				keys = append(keys, string(r))
+			}
+		}
+	}
+	return keys
+}
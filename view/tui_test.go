@@ -0,0 +1,105 @@
+package view
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTUI(t *testing.T) *TUI {
+	tui, err := NewTUI(filepath.Join("..", "testdata", "goodfile.ini"))
+	assert.Nil(t, err)
+	return tui
+}
+
+func visibleNames(tui *TUI) []string {
+	names := []string{}
+	for _, game := range tui.visible {
+		names = append(names, game.td.Name)
+	}
+	return names
+}
+
+func TestNewTUI(t *testing.T) {
+	_, err := NewTUI(filepath.Join("..", "testdata", "non-existent.ini"))
+	assert.NotNil(t, err)
+
+	tui := newTestTUI(t)
+	expected := []string{"Accordion", "Agnes", "Block Ten", "Canfield", "Freecell", "Klondike", "Spider"}
+	assert.Equal(t, expected, visibleNames(tui))
+}
+
+func TestTUI_HandleKey(t *testing.T) {
+	tui := newTestTUI(t)
+
+	// Movement stays within the list
+	tui.HandleKey(keyUp, 24)
+	assert.Equal(t, 0, tui.selected)
+	tui.HandleKey("j", 24)
+	tui.HandleKey(keyDown, 24)
+	assert.Equal(t, "Block Ten", tui.visible[tui.selected].td.Name)
+	tui.HandleKey(keyEnd, 24)
+	tui.HandleKey(keyDown, 24)
+	assert.Equal(t, "Spider", tui.visible[tui.selected].td.Name)
+
+	// Sorting by games played keeps the selection
+	tui.HandleKey("s", 24)
+	assert.Equal(t, tuiSortPlayed, tui.sortBy)
+	assert.Equal(t, "Spider", tui.visible[tui.selected].td.Name)
+	assert.Equal(t, "Freecell", tui.visible[len(tui.visible)-2].td.Name)
+	tui.HandleKey("r", 24)
+	assert.Equal(t, "Spider", tui.visible[0].td.Name)
+
+	// Searching by display name or section name
+	tui.HandleKey("/", 24)
+	for _, key := range []string{"b", "l", "o", "c", "k", "-"} {
+		tui.HandleKey(key, 24)
+	}
+	assert.Equal(t, []string{"Block Ten"}, visibleNames(tui))
+	tui.HandleKey(keyBackspace, 24)
+	assert.Equal(t, "block", tui.query)
+	tui.HandleKey(keyEscape, 24)
+	assert.Equal(t, "", tui.query)
+	assert.Equal(t, 7, len(tui.visible))
+
+	// Quitting
+	assert.False(t, tui.HandleKey("x", 24))
+	assert.True(t, tui.HandleKey("q", 24))
+}
+
+func TestTUI_Render(t *testing.T) {
+	tui := newTestTUI(t)
+	tui.HandleKey(keyEnd, 10)
+	lines := tui.Render(80, 10)
+	assert.Equal(t, 10, len(lines))
+	assert.Contains(t, lines[0], "7 games")
+	assert.Contains(t, lines[1], "Game name:")
+	assert.Contains(t, lines[1], "Spider")
+	assert.True(t, strings.HasPrefix(lines[9], " ↑/↓ move"))
+	for _, line := range lines {
+		assert.LessOrEqual(t, DisplayWidth(line), 80)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{"letters", "jk", []string{"j", "k"}},
+		{"arrows", "\x1b[A\x1b[B", []string{keyUp, keyDown}},
+		{"escape", "\x1b", []string{keyEscape}},
+		{"enter", "\r", []string{keyEnter}},
+		{"backspace", "\x7f", []string{keyBackspace}},
+		{"interrupt", "\x03", []string{keyInterrupt}},
+		{"unicode", "é", []string{"é"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseKeys([]byte(tt.data)))
+		})
+	}
+}
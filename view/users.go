@@ -0,0 +1,82 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/philhanna/aisleriot/model"
+)
+
+// PrintUsers writes a table comparing the statistics of several users,
+// for the specified games together, or for every game if none are
+// specified. Users whose statistics cannot be read, including users
+// with a game whose statistics are impossible, are listed after the
+// table.
+func PrintUsers(w io.Writer, users []*model.User, gameNames ...string) {
+	if len(users) == 0 {
+		fmt.Fprintln(w, T("No users with statistics were found"))
+		return
+	}
+	loc := CurrentLocale
+	header := []string{T("User"), T("Games"), T("Played"), T("Wins"), T("Win %"), T("Best")}
+	rows := [][]string{}
+	pcts := []int{}
+	failed := []*model.User{}
+	for _, u := range users {
+		pdp, err := u.DataProvider()
+		if err != nil {
+			failed = append(failed, &model.User{Name: u.Name, Err: err})
+			continue
+		}
+		stats := []*model.Statistics{}
+		games := 0
+		var gameErr error
+		for _, sName := range gameSections(pdp, gameNames) {
+			game, err := pdp.Game(sName)
+			if errors.Is(err, model.ErrGameNotFound) {
+				continue
+			}
+			if err != nil {
+				gameErr = err
+				break
+			}
+			if !game.HasStats {
+				continue
+			}
+			stats = append(stats, game.Stats)
+			if game.Stats.Total() > 0 {
+				games++
+			}
+		}
+		if gameErr != nil {
+			failed = append(failed, &model.User{Name: u.Name, Err: gameErr})
+			continue
+		}
+		ps := model.Total(stats...)
+		rows = append(rows, []string{
+			u.Name,
+			loc.FormatInt(games),
+			loc.FormatInt(ps.Total()),
+			loc.FormatInt(ps.Wins()),
+			loc.FormatStatsPercent(ps),
+			statTime(ps, ps.Best()),
+		})
+		pcts = append(pcts, ps.Percentage())
+	}
+	if len(rows) > 0 {
+		const pctColumn = 4
+		writeTable(w, header, rows, func(row, col int, cell string) string {
+			if col == pctColumn {
+				return colorPercent(cell, pcts[row])
+			}
+			return cell
+		})
+	}
+	if len(rows) > 0 && len(failed) > 0 {
+		fmt.Fprintln(w)
+	}
+	for _, u := range failed {
+		fmt.Fprintf(w, T("Cannot read the statistics of %s: %v")+"\n", u.Name, u.Err)
+	}
+}
@@ -0,0 +1,56 @@
+package view
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/philhanna/aisleriot/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintUsers(t *testing.T) {
+	users := []*model.User{
+		{Name: "alice", Filename: filepath.Join("..", "testdata", "aisleriot")},
+		{Name: "bob", Err: errors.New("permission denied")},
+	}
+	filename := filepath.Join(t.TempDir(), "aisleriot")
+	assert.Nil(t, os.WriteFile(filename, []byte("[freecell.scm]\nStatistic=3;2;60;60;\n"), 0o644))
+	carol := &model.User{Name: "carol", Filename: filename}
+	tests := []struct {
+		name      string
+		users     []*model.User
+		gameNames []string
+		want      []string
+	}{
+		{"all games", users, nil, []string{
+			"User   Games  Played  Wins  Win %   Best",
+			"alice      4     455   220    48%  01:28",
+			"",
+			"Cannot read the statistics of bob: permission denied",
+		}},
+		{"some games", users[:1], []string{"spider", "klondike"}, []string{
+			"User   Games  Played  Wins  Win %   Best",
+			"alice      2     245    45    18%  07:59",
+		}},
+		{"impossible statistics", []*model.User{users[0], carol}, nil, []string{
+			"User   Games  Played  Wins  Win %   Best",
+			"alice      4     455   220    48%  01:28",
+			"",
+			"Cannot read the statistics of carol: freecell.scm: impossible statistics: wins (3) greater than total (2)",
+		}},
+		{"no users", nil, nil, []string{
+			"No users with statistics were found",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintUsers(&buf, tt.users, tt.gameNames...)
+			assert.Equal(t, tt.want, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"))
+		})
+	}
+}
@@ -3,7 +3,9 @@ package view
 import (
 	"fmt"
 	"github.com/philhanna/aisleriot/model"
+	"io"
 	"log"
+	"strconv"
 	"strings"
 )
 
@@ -15,92 +17,175 @@ func List(pdp *model.DataProvider) {
 	gameNames := pdp.GameList()
 	if gameNames != nil {
 		for i, gameName := range pdp.GameList() {
-			fmt.Printf("%d: %s\n", i+1, model.ToDisplayName(gameName))
+			line := fmt.Sprintf("%d: %s", i+1, model.ToDisplayName(gameName))
+			fmt.Println(Truncate(line, TerminalWidth()))
 		}
 	} else {
-		fmt.Printf("No games have been played\n")
+		fmt.Println(T("No games have been played"))
 	}
 
 }
 
-// Prints the statistics for the specified game
-func PrintStatistics(pdp *model.DataProvider, gameName string) {
-	sName := model.ToSectionName(gameName)
-	section, ok := pdp.Sections[sName]
-	if !ok {
-		log.Fatalf("Game %q not found\n", gameName)
+// ListAll prints every game in the statistics file: the games in the
+// Recent list, numbered from the most recent, and then the other games
+// with statistics
+func ListAll(pdp *model.DataProvider) {
+	items := pdp.Inventory()
+	if len(items) == 0 {
+		fmt.Println(T("No games have been played"))
+		return
 	}
+	width := 1
+	for _, item := range items {
+		if n := len(strconv.Itoa(item.Position)); n > width {
+			width = n
+		}
+	}
+	for _, item := range items {
+		position := "-"
+		if item.Position > 0 {
+			position = strconv.Itoa(item.Position)
+		}
+		line := fmt.Sprintf("%*s: %s", width, position, model.ToDisplayName(item.Name))
+		switch item.Status {
+		case model.GameNotRecent:
+			line += " (" + T("not recently played") + ")"
+		case model.GameNoStats:
+			line += " (" + T("no statistics") + ")"
+		}
+		fmt.Println(Truncate(line, TerminalWidth()))
+	}
+}
 
-	// Get the statistics for this game
-	statString := section[model.StatsKey]
-	ps, err := model.NewStatisticsFromString(statString)
+// Prints the statistics for the specified game
+func PrintStatistics(pdp *model.DataProvider, gameName string) {
+	td, err := NewTemplateData(pdp, gameName)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Start forming the list of statistical strings
-	parts := make([]string, 0)
-	parts = append(parts, "Game name:")
-	parts = append(parts, "Number of wins:")
-	parts = append(parts, "Number of losses:")
-	parts = append(parts, "Total games played:")
-	parts = append(parts, "Best time:")
-	parts = append(parts, "Average time:")
-	parts = append(parts, "Worst time:")
-	parts = append(parts, "Winning percentage:")
-	parts = append(parts, fmt.Sprintf("Number of wins to %d%%:", ps.Percentage()+1))
-	parts = append(parts, fmt.Sprintf("Number of losses to %d%%:", ps.Percentage()-1))
+	// Join the lines with newlines and print
+	stats := strings.Join(FormatStatistics(td, TerminalWidth()), "\n")
+	fmt.Println(stats)
+}
 
-	// Pad them all to the length of the longest part
-	parts = PadParts(parts)
+// FormatStatistics returns the labeled lines of statistics for a game.
+// Values are truncated so that lines are no wider than width, unless
+// width is zero.
+func FormatStatistics(td *TemplateData, width int) []string {
+	ps := td.Stats
 
-	// Append the statistic to each line
-	parts[0] += fmt.Sprintf(" %s", gameName)
-	parts[1] += fmt.Sprintf(" %d", ps.Wins())
-	parts[2] += fmt.Sprintf(" %d", ps.Losses())
-	parts[3] += fmt.Sprintf(" %d", ps.Total())
-	parts[4] += fmt.Sprintf(" %s", SecondsToTime(ps.Best()))
-	parts[5] += fmt.Sprintf(" %s", SecondsToTime(ps.Average()))
-	parts[6] += fmt.Sprintf(" %s", SecondsToTime(ps.Worst()))
-	parts[7] += fmt.Sprintf(" %d%%", ps.Percentage())
-	parts[8] += fmt.Sprintf(" %d", ps.WinsToNextHigher())
-	parts[9] += fmt.Sprintf(" %d", ps.LossesToNextLower())
-	if strings.HasSuffix(parts[9], "-1") {
-		parts = parts[:9]
-	}
-	if strings.HasSuffix(parts[8], "-1") {
-		parts = parts[:8]
+	// Form the list of labels and their values. The "to next percent"
+	// lines are only shown when they are meaningful.
+	// The optional style function colors the value.
+	type row struct {
+		label string
+		value string
+		style func(string) string
+	}
+	loc := CurrentLocale
+	bestStyle := func(s string) string {
+		if !ps.HasTimes() {
+			return s
+		}
+		return colorize(s, ansiBold)
+	}
+	pctStyle := func(s string) string {
+		return colorPercent(s, ps.Percentage())
+	}
+	name := td.Name
+	if !td.HasStats {
+		name += " (" + T("no statistics") + ")"
+	}
+	rows := []row{
+		{T("Game name:"), name, nil},
+		{T("Number of wins:"), loc.FormatInt(ps.Wins()), nil},
+		{T("Number of losses:"), loc.FormatInt(ps.Losses()), nil},
+		{T("Total games played:"), loc.FormatInt(ps.Total()), nil},
+		{T("Best time:"), statTime(ps, ps.Best()), bestStyle},
+		{T("Average time:"), statTime(ps, ps.Average()), nil},
+		{T("Worst time:"), statTime(ps, ps.Worst()), nil},
+		{T("Winning percentage:"), loc.FormatStatsPercent(ps), pctStyle},
+	}
+	if n := ps.WinsToNextHigher(); n != -1 {
+		label := fmt.Sprintf(T("Number of wins to %s:"), loc.FormatPercent(ps.Percentage()+1))
+		rows = append(rows, row{label, loc.FormatInt(n), nil})
+	}
+	if n := ps.LossesToNextLower(); n != -1 {
+		label := fmt.Sprintf(T("Number of losses to %s:"), loc.FormatPercent(ps.Percentage()-1))
+		rows = append(rows, row{label, loc.FormatInt(n), nil})
 	}
 
-	// Join parts with newlines and print
-	stats := strings.Join(parts, "\n")
-	fmt.Println(stats)
+	// Pad the labels to the width of the longest one
+	parts := make([]string, len(rows))
+	for i, r := range rows {
+		parts[i] = r.label
+	}
+	parts = PadParts(parts)
+
+	// Append the statistic to each line, truncating it if it would not
+	// fit on the terminal, then color the percentage and best time
+	valueWidth := 0
+	if width > 0 {
+		valueWidth = width - DisplayWidth(parts[0]) - 1
+	}
+	for i, r := range rows {
+		value := Truncate(r.value, valueWidth)
+		if r.style != nil {
+			value = r.style(value)
+		}
+		parts[i] += " " + value
+	}
+	return parts
 }
 
-// PadParts pads all the strings to the length of the longest part
+// PadParts pads all the strings to the display width of the widest
+// part
 func PadParts(parts []string) []string {
-	maxLen := -1
+	maxWidth := 0
 	for _, s := range parts {
-		if len(s) > maxLen {
-			maxLen = len(s)
+		if w := DisplayWidth(s); w > maxWidth {
+			maxWidth = w
 		}
 	}
 	newParts := make([]string, len(parts))
 	for i, s := range parts {
-		for len(s) < maxLen {
-			s += " "
-		}
-		newParts[i] = s
+		newParts[i] = PadRight(s, maxWidth)
 	}
 	return newParts
 }
 
-// SecondsToTime converts a number of seconds into a mm:ss string
+// SecondsToTime converts a number of seconds into a string in the
+// default time format
 func SecondsToTime(seconds int) string {
-	if seconds == 0 {
-		return "N/A"
+	return DefaultTimeFormat.Format(seconds)
+}
+
+// statTime formats one of the times in the statistics, or returns
+// NoTime if no winning game has been recorded.
+func statTime(ps *model.Statistics, seconds int) string {
+	if !ps.HasTimes() {
+		return T(NoTime)
+	}
+	return SecondsToTime(seconds)
+}
+
+// PrintGoal writes the number of wins in a row needed to raise the
+// winning percentage for a game to pct
+func PrintGoal(w io.Writer, pdp *model.DataProvider, gameName string, pct int) error {
+	td, err := NewTemplateData(pdp, gameName)
+	if err != nil {
+		return err
+	}
+	loc := CurrentLocale
+	target := loc.FormatPercent(pct)
+	switch n := td.Stats.WinsToReach(pct); n {
+	case -1:
+		fmt.Fprintf(w, T("%s: %s cannot be reached")+"\n", td.Name, target)
+	case 0:
+		fmt.Fprintf(w, T("%s: already at %s")+"\n", td.Name, target)
+	default:
+		fmt.Fprintf(w, T("%s: %s wins in a row to reach %s")+"\n", td.Name, loc.FormatInt(n), target)
 	}
-	mm := int(seconds / 60)
-	ss := seconds % 60
-	return fmt.Sprintf("%02d:%02d", mm, ss)
+	return nil
 }
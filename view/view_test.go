@@ -1,8 +1,11 @@
 package view
 
 import (
+	"bytes"
+	"path/filepath"
 	"testing"
 
+	"github.com/philhanna/aisleriot/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,3 +24,26 @@ func Test_padParts(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintGoal(t *testing.T) {
+	pdp, err := model.NewDataProvider(filepath.Join("..", "testdata", "aisleriot"))
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		gameName string
+		pct      int
+		expected string
+	}{
+		{"reachable", "Spider", 25, "Spider: 20 wins in a row to reach 25%\n"},
+		{"reached", "Freecell", 50, "Freecell: already at 50%\n"},
+		{"unreachable", "Klondike", 100, "Klondike: 100% cannot be reached\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintGoal(&buf, pdp, tt.gameName, tt.pct)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package view
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ---------------------------------------------------------------------
+// Variables
+// ---------------------------------------------------------------------
+
+var (
+	// Matches ANSI SGR escape sequences, which take up no columns
+	reANSI = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	// Ranges of characters that take up two columns in a terminal
+	wideRanges = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x1100, Hi: 0x115F, Stride: 1}, // Hangul Jamo
+			{Lo: 0x2E80, Hi: 0x303E, Stride: 1}, // CJK radicals, punctuation
+			{Lo: 0x3041, Hi: 0x33FF, Stride: 1}, // Kana, CJK compatibility
+			{Lo: 0x3400, Hi: 0x4DBF, Stride: 1}, // CJK extension A
+			{Lo: 0x4E00, Hi: 0x9FFF, Stride: 1}, // CJK unified ideographs
+			{Lo: 0xA000, Hi: 0xA4CF, Stride: 1}, // Yi
+			{Lo: 0xAC00, Hi: 0xD7A3, Stride: 1}, // Hangul syllables
+			{Lo: 0xF900, Hi: 0xFAFF, Stride: 1}, // CJK compatibility ideographs
+			{Lo: 0xFE30, Hi: 0xFE4F, Stride: 1}, // CJK compatibility forms
+			{Lo: 0xFF00, Hi: 0xFF60, Stride: 1}, // Fullwidth forms
+			{Lo: 0xFFE0, Hi: 0xFFE6, Stride: 1}, // Fullwidth signs
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x1F300, Hi: 0x1F64F, Stride: 1}, // Pictographs, emoticons
+			{Lo: 0x1F900, Hi: 0x1F9FF, Stride: 1}, // Supplemental pictographs
+			{Lo: 0x20000, Hi: 0x3FFFD, Stride: 1}, // CJK extensions B and later
+		},
+	}
+)
+
+// ---------------------------------------------------------------------
+// Functions
+// ---------------------------------------------------------------------
+
+// DisplayWidth returns the number of terminal columns needed to show
+// the string. Combining marks and ANSI color sequences take no columns,
+// and East Asian wide characters take two.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range reANSI.ReplaceAllString(s, "") {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// PadRight appends spaces to the string until its display width is the
+// specified width.
+func PadRight(s string, width int) string {
+	n := width - DisplayWidth(s)
+	if n <= 0 {
+		return s
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+// Truncate shortens a string without color sequences so that its
+// display width is no more than the specified width, ending it with an
+// ellipsis if anything was removed.
+func Truncate(s string, width int) string {
+	if width <= 0 || DisplayWidth(s) <= width {
+		return s
+	}
+	var sb strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if used+rw > width-1 {
+			break
+		}
+		sb.WriteRune(r)
+		used += rw
+	}
+	sb.WriteString("…")
+	return sb.String()
+}
+
+// TerminalWidth returns the width of the terminal on standard output,
+// taken from $COLUMNS if set, or zero if it cannot be determined.
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return terminalWidth(os.Stdout)
+}
+
+// runeWidth returns the number of columns a single character takes
+func runeWidth(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.Is(wideRanges, r):
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,60 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected int
+	}{
+		{"empty", "", 0},
+		{"ascii", "Spider", 6},
+		{"accented", "Défaites", 8},
+		{"combining", "De\u0301faites", 8},
+		{"wide", "蜘蛛", 4},
+		{"colored", "\x1b[32m45%\x1b[0m", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DisplayWidth(tt.s))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		expected string
+	}{
+		{"fits", "Spider", 6, "Spider"},
+		{"no limit", "Spider", 0, "Spider"},
+		{"too long", "Auld Lang Syne", 8, "Auld La…"},
+		{"wide", "蜘蛛蜘蛛", 5, "蜘蛛…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := Truncate(tt.s, tt.width)
+			assert.Equal(t, tt.expected, actual)
+			if tt.width > 0 {
+				assert.LessOrEqual(t, DisplayWidth(actual), tt.width)
+			}
+		})
+	}
+}
+
+func TestPadParts(t *testing.T) {
+	parts := PadParts([]string{"Défaites :", "Nom :", "蜘蛛:"})
+	assert.Equal(t, []string{"Défaites :", "Nom :     ", "蜘蛛:     "}, parts)
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "42")
+	assert.Equal(t, 42, TerminalWidth())
+}